@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	CredentialsEnvURI     = "KOWABUNGA_URI"
+	CredentialsEnvToken   = "KOWABUNGA_TOKEN"
+	CredentialsEnvProfile = "KOWABUNGA_PROFILE"
+	CredentialsEnvFile    = "KOWABUNGA_CREDENTIALS_FILE"
+
+	CredentialsDefaultProfile = "default"
+	CredentialsDefaultFile    = ".kowabunga/credentials" // relative to user's home directory
+
+	CredentialsErrUnknownProfile = "unknown profile '%s' in credentials file %s"
+	CredentialsErrSyntax         = "%s:%d: invalid syntax, expected 'key = value'"
+)
+
+// returns the credentials file to be used, if any
+func credentialsFile(file string) string {
+	if file != "" {
+		return file
+	}
+	if file = os.Getenv(CredentialsEnvFile); file != "" {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, CredentialsDefaultFile)
+}
+
+// parses INI-like credentials file, with one section per named profile:
+//
+//	[default]
+//	uri = https://kowabunga.acme.com
+//	token = <API key>
+func credentialsFileProfiles(file string) (map[string]map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	profiles := map[string]map[string]string{}
+	profile := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			profile = strings.TrimSpace(line[1 : len(line)-1])
+			profiles[profile] = map[string]string{}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || profile == "" {
+			return nil, fmt.Errorf(CredentialsErrSyntax, file, n)
+		}
+		profiles[profile][strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return profiles, scanner.Err()
+}
+
+// resolves provider's URI and token, by order of precedence: from provider's
+// configuration, its configured credentials file profile, environment
+// variables, then environment or default credentials file profile
+func providerCredentials(uri, token, profile, file string) (string, string, error) {
+	// an explicitly configured profile wins over environment variables
+	if profile != "" {
+		return profileCredentials(uri, token, profile, file, true)
+	}
+
+	if uri == "" {
+		uri = os.Getenv(CredentialsEnvURI)
+	}
+	if token == "" {
+		token = os.Getenv(CredentialsEnvToken)
+	}
+	if uri != "" && token != "" {
+		return uri, token, nil
+	}
+
+	profile = os.Getenv(CredentialsEnvProfile)
+	explicit := profile != ""
+	if profile == "" {
+		profile = CredentialsDefaultProfile
+	}
+	return profileCredentials(uri, token, profile, file, explicit)
+}
+
+// completes URI and token from a credentials file profile, then from
+// environment variables
+func profileCredentials(uri, token, profile, file string, explicit bool) (string, string, error) {
+	file = credentialsFile(file)
+	if file == "" {
+		return uri, token, nil
+	}
+	profiles, err := credentialsFileProfiles(file)
+	if err != nil {
+		// credentials file is optional, unless a profile is explicitly requested
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return uri, token, nil
+		}
+		return uri, token, err
+	}
+
+	p, ok := profiles[profile]
+	if !ok {
+		if !explicit {
+			return uri, token, nil
+		}
+		return uri, token, fmt.Errorf(CredentialsErrUnknownProfile, profile, file)
+	}
+	if uri == "" {
+		uri = p[KeyURI]
+	}
+	if token == "" {
+		token = p[KeyToken]
+	}
+	if uri == "" {
+		uri = os.Getenv(CredentialsEnvURI)
+	}
+	if token == "" {
+		token = os.Getenv(CredentialsEnvToken)
+	}
+
+	return uri, token, nil
+}
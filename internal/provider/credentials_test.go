@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviderCredentialsPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	err := os.WriteFile(file, []byte(`
+[default]
+uri = https://default
+token = default-token
+
+[prod]
+uri = https://prod
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		uri        string
+		profile    string
+		envURI     string
+		envProfile string
+		wantURI    string
+		wantToken  string
+	}{
+		{"default profile", "", "", "", "", "https://default", "default-token"},
+		{"environment over default profile", "", "", "https://env", "", "https://env", "env-token"},
+		{"environment over environment profile", "", "", "https://env", "prod", "https://env", "env-token"},
+		{"configured profile over environment", "", "prod", "https://env", "", "https://prod", "env-token"},
+		{"configured URI over configured profile", "https://config", "prod", "https://env", "", "https://config", "env-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(CredentialsEnvURI, tt.envURI)
+			t.Setenv(CredentialsEnvProfile, tt.envProfile)
+			token := ""
+			if tt.envURI != "" {
+				token = "env-token"
+			}
+			t.Setenv(CredentialsEnvToken, token)
+
+			uri, token, err := providerCredentials(tt.uri, "", tt.profile, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uri != tt.wantURI || token != tt.wantToken {
+				t.Errorf("got %s/%s, want %s/%s", uri, token, tt.wantURI, tt.wantToken)
+			}
+		})
+	}
+}
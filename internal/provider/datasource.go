@@ -23,11 +23,6 @@ const (
 	DataSourceNameDescription = "Datasource name"
 )
 
-type GenericDataSourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
-}
-
 func datasourceMetadata(req datasource.MetadataRequest, resp *datasource.MetadataResponse, name string) {
 	resp.TypeName = req.ProviderTypeName + "_" + name
 }
@@ -57,26 +52,6 @@ func datasourceConfigure(req datasource.ConfigureRequest, resp *datasource.Confi
 	return kd
 }
 
-func datasourceAttributes() map[string]schema.Attribute {
-	return map[string]schema.Attribute{
-		KeyID: schema.StringAttribute{
-			Computed:            true,
-			MarkdownDescription: DataSourceIdDescription,
-		},
-		KeyName: schema.StringAttribute{
-			MarkdownDescription: DataSourceNameDescription,
-			Required:            true,
-		},
-	}
-}
-
-func datasourceFilteredSchema(resp *datasource.SchemaResponse, rs string) {
-	resp.Schema = schema.Schema{
-		MarkdownDescription: fmt.Sprintf("Data from a %s resource", rs),
-		Attributes:          datasourceAttributes(),
-	}
-}
-
 func datasourceFullSchema(resp *datasource.SchemaResponse, rs string) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: fmt.Sprintf("Data from %s", rs),
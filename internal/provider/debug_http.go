@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DebugHTTPRedacted = "<redacted>"
+)
+
+// HTTP headers whose value must never be logged
+var debugHTTPSensitiveHeadersRegexp = regexp.MustCompile(`(?im)^(Authorization|Cookie|Set-Cookie|X-API-Key):.*$`)
+
+// JSON payload fields whose value must never be logged, including generic
+// "value" ones (e.g. API tokens and passwords, but also metadata values)
+var debugHTTPSensitiveFieldsRegexp = regexp.MustCompile(`"(password|root_password|pre_shared_key|ceph_secret_uuid|token|api_key|secret|value)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// debugHTTPTransport logs sanitized HTTP requests and responses payloads
+type debugHTTPTransport struct {
+	transport http.RoundTripper
+}
+
+// redacts sensitive headers and payload fields from an HTTP dump
+func debugHTTPRedact(dump []byte) string {
+	s := debugHTTPSensitiveHeadersRegexp.ReplaceAllString(string(dump), "$1: "+DebugHTTPRedacted+"\r")
+	return debugHTTPSensitiveFieldsRegexp.ReplaceAllString(s, `"$1":"`+DebugHTTPRedacted+`"`)
+}
+
+func (t *debugHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err == nil {
+		tflog.Debug(ctx, "HTTP request", map[string]any{
+			"payload": debugHTTPRedact(dump),
+		})
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	dump, err = httputil.DumpResponse(resp, true)
+	if err == nil {
+		tflog.Debug(ctx, "HTTP response", map[string]any{
+			"payload": debugHTTPRedact(dump),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDebugHTTPRedact(t *testing.T) {
+	dump := "HTTP/1.1 200 OK\r\nSet-Cookie: session=abc\r\n\r\n" +
+		`{"name":"ci","value":"s3cr\"et","root_password": "hunter2","pre_shared_key":"psk","desc":"kept"}`
+
+	got := debugHTTPRedact([]byte(dump))
+	for _, secret := range []string{"abc", `s3cr\"et`, "hunter2", "psk"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("secret %q not redacted: %s", secret, got)
+		}
+	}
+	if !strings.Contains(got, `"desc":"kept"`) {
+		t.Errorf("non-sensitive field redacted: %s", got)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DeprecationWarning            = "Deprecated attribute"
+	DeprecationWarningChange      = "Changed attribute"
+	DeprecationMessageValue       = "`%s` value: "
+	DeprecationMessageSince       = "Deprecated since provider %s"
+	DeprecationMessageReplacement = ", use %s instead"
+	DeprecationMessageRemoval     = ". It will be removed in the next major release."
+	DeprecationMessageChange      = "Changed in provider %s: %s."
+	DeprecationMessageUsages      = "%s Used %d time(s) so far."
+)
+
+// deprecatedAttribute describes a resource attribute (or one of its values)
+// scheduled for removal (e.g. upcoming rename), or subject to a breaking
+// change (e.g. list to set conversion). Its Message() is to be used as schema
+// attribute's DeprecationMessage (or in its description for a deprecated
+// value or a changed attribute), while deprecationWarnings() reports its
+// actual usage at plan time.
+type deprecatedAttribute struct {
+	Path        path.Path
+	Value       string // deprecated string value, whole attribute if empty
+	Since       string // provider release the attribute got deprecated or changed in
+	Replacement string // replacement attribute, if any
+	Change      string // breaking change of a kept attribute, if any
+}
+
+func (d deprecatedAttribute) Message() string {
+	if d.Change != "" {
+		return fmt.Sprintf(DeprecationMessageChange, d.Since, d.Change)
+	}
+	msg := fmt.Sprintf(DeprecationMessageSince, d.Since)
+	if d.Value != "" {
+		msg = fmt.Sprintf(DeprecationMessageValue, d.Value) + msg
+	}
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(DeprecationMessageReplacement, d.Replacement)
+	}
+	return msg + DeprecationMessageRemoval
+}
+
+// deprecationUsages counts deprecated attributes usages, per resource type
+// and attribute, for the lifetime of a configured provider
+type deprecationUsages struct {
+	mutex  sync.Mutex
+	counts map[string]int
+}
+
+func newDeprecationUsages() *deprecationUsages {
+	return &deprecationUsages{
+		counts: map[string]int{},
+	}
+}
+
+// registers a new usage, returns the overall usage count and whether it
+// deserves a warning: only the 1st, 2nd, 4th, 8th ... usages are reported so
+// that large configurations don't get flooded with the very same warning
+func (u *deprecationUsages) add(key string) (int, bool) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.counts[key]++
+	count := u.counts[key]
+	return count, count&(count-1) == 0
+}
+
+// deprecationWarnings reports deprecated attributes set in resource's
+// configuration (to be called from ModifyPlan), with their usage counts
+func deprecationWarnings(ctx context.Context, usages *deprecationUsages, resourceName string, config tfsdk.Config, attrs []deprecatedAttribute, diags *diag.Diagnostics) {
+	for _, d := range attrs {
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, d.Path, &value)...)
+		if diags.HasError() {
+			return
+		}
+		if value == nil || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if s, ok := value.(types.String); ok && d.Value != "" && s.ValueString() != d.Value {
+			continue
+		}
+
+		count, warn := usages.add(resourceName + "." + d.Path.String())
+		tflog.Debug(ctx, "deprecated attribute usage", map[string]any{
+			"resource":  resourceName,
+			"attribute": d.Path.String(),
+			"count":     count,
+		})
+		if warn {
+			summary := DeprecationWarning
+			if d.Change != "" {
+				summary = DeprecationWarningChange
+			}
+			diags.AddAttributeWarning(d.Path, summary, fmt.Sprintf(DeprecationMessageUsages, d.Message(), count))
+		}
+	}
+}
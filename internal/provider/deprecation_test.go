@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	ctx := context.Background()
+	s := testResourceSchema(NewInstanceResource())
+	config := func(state string) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: s,
+			Raw: testResourceValue(s, map[string]tftypes.Value{
+				KeyState: tftypes.NewValue(tftypes.String, state),
+			}),
+		}
+	}
+
+	deprecated := []deprecatedAttribute{
+		{Path: path.Root(KeyState), Value: PowerStateStopped, Since: "1.0.0"},
+	}
+
+	// only deprecated value is reported, 1st, 2nd and 4th usages only
+	usages := newDeprecationUsages()
+	warnings := 0
+	for i, state := range []string{PowerStateRunning, PowerStateStopped, PowerStateStopped, PowerStateStopped, PowerStateStopped} {
+		var diags diag.Diagnostics
+		deprecationWarnings(ctx, usages, InstanceResourceName, config(state), deprecated, &diags)
+		if diags.HasError() {
+			t.Fatalf("#%d: %v", i, diags)
+		}
+		warnings += diags.WarningsCount()
+	}
+	if warnings != 3 {
+		t.Errorf("got %d warnings, want 3", warnings)
+	}
+
+	// usages are counted per configured provider
+	var diags diag.Diagnostics
+	deprecationWarnings(ctx, newDeprecationUsages(), InstanceResourceName, config(PowerStateStopped), deprecated, &diags)
+	if diags.WarningsCount() != 1 {
+		t.Errorf("got %d warnings from a new provider, want 1", diags.WarningsCount())
+	}
+}
+
+func TestDeprecationWarningsChange(t *testing.T) {
+	ctx := context.Background()
+	s := testResourceSchema(NewKyloResource())
+	config := func(protocols tftypes.Value) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: s,
+			Raw: testResourceValue(s, map[string]tftypes.Value{
+				KeyProtocols: protocols,
+			}),
+		}
+	}
+	set := tftypes.Set{ElementType: tftypes.Number}
+
+	// defaulted attribute isn't reported
+	var diags diag.Diagnostics
+	deprecationWarnings(ctx, newDeprecationUsages(), KyloResourceName, config(tftypes.NewValue(set, nil)), kyloChangedAttributes, &diags)
+	if diags.WarningsCount() != 0 {
+		t.Errorf("got %d warnings for unset attribute, want none", diags.WarningsCount())
+	}
+
+	deprecationWarnings(ctx, newDeprecationUsages(), KyloResourceName, config(tftypes.NewValue(set, []tftypes.Value{
+		tftypes.NewValue(tftypes.Number, 4),
+	})), kyloChangedAttributes, &diags)
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != DeprecationWarningChange {
+		t.Errorf("got %v, want a single %q warning", diags, DeprecationWarningChange)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"sync"
+)
+
+// dnsRecordIndex keeps track of the FQDN of each known DNS record of a
+// project or region, for the lifetime of the provider, so that ownership
+// checks don't read all records of a parent over and over. Unlike idCache,
+// entries don't expire: the parent's records IDs are to be listed on each
+// check, only records never seen before being read, and records no longer
+// listed being dropped, so that records created or deleted elsewhere are
+// always accounted for.
+type dnsRecordIndex struct {
+	mutex   sync.Mutex
+	parents map[string]map[string]string // parent ID -> record ID -> FQDN
+}
+
+func newDnsRecordIndex() *dnsRecordIndex {
+	return &dnsRecordIndex{
+		parents: map[string]map[string]string{},
+	}
+}
+
+// syncs parent's records with the list of its current ones, returns the IDs
+// of those yet to be read and set
+func (x *dnsRecordIndex) unknown(parentId string, ids []string) []string {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	records, ok := x.parents[parentId]
+	if !ok {
+		records = map[string]string{}
+		x.parents[parentId] = records
+	}
+
+	listed := map[string]bool{}
+	unknown := []string{}
+	for _, id := range ids {
+		listed[id] = true
+		if _, ok := records[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	for id := range records {
+		if !listed[id] {
+			delete(records, id)
+		}
+	}
+	return unknown
+}
+
+func (x *dnsRecordIndex) set(parentId, id, fqdn string) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	records, ok := x.parents[parentId]
+	if !ok {
+		records = map[string]string{}
+		x.parents[parentId] = records
+	}
+	records[id] = fqdn
+}
+
+// returns the ID of parent's record with a given FQDN, if any
+func (x *dnsRecordIndex) owner(parentId, fqdn string) (string, bool) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	for id, f := range x.parents[parentId] {
+		if f == fqdn {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// drops records, to be called whenever they get renamed or deleted, for them
+// to be read again on next check
+func (x *dnsRecordIndex) forget(ids ...string) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	for _, records := range x.parents {
+		for _, id := range ids {
+			delete(records, id)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	IDCacheDefaultTTL = 5 * time.Minute
+)
+
+// idCache keeps track of resolved resources name (or ID) to ID mappings,
+// shared by all resources for the lifetime of the provider, so that
+// name-based references don't trigger a full list and read of all objects
+// of a kind on every lookup. Entries are per resource kind, optionally
+// scoped to a parent (e.g. templates per storage pool).
+type idCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]idCacheEntry
+}
+
+type idCacheEntry struct {
+	id      string
+	expires time.Time
+}
+
+func newIDCache(ttl time.Duration) *idCache {
+	return &idCache{
+		ttl:     ttl,
+		entries: map[string]idCacheEntry{},
+	}
+}
+
+func idCacheKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+func (c *idCache) get(kind, name string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := idCacheKey(kind, name)
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return e.id, true
+}
+
+func (c *idCache) set(kind, name, id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[idCacheKey(kind, name)] = idCacheEntry{
+		id:      id,
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+// drops all entries of a given resource kind, to be called whenever one of
+// its objects gets created, renamed or deleted
+func (c *idCache) invalidate(kind string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	prefix := kind + "/"
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
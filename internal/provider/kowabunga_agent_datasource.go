@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	AgentDataSourceName            = "agent"
+	AgentDataSourceDescDescription = "Datasource description"
+
+	AgentDataSourceErrNotFound = "no such agent"
+)
+
+type AgentDataSourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Desc types.String `tfsdk:"desc"`
+	Type types.String `tfsdk:"type"`
+}
+
+func agentDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: DataSourceNameDescription,
+			Required:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: AgentDataSourceDescDescription,
+			Computed:            true,
+		},
+		KeyType: schema.StringAttribute{
+			MarkdownDescription: "Kowabunga remote agent type (" + strings.Join(agentSupportedTypes, ", ") + "), used as a filter if specified",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				&stringAgentTypeValidator{},
+			},
+		},
+	}
+}
+
+var _ datasource.DataSource = &AgentDataSource{}
+var _ datasource.DataSourceWithConfigure = &AgentDataSource{}
+
+func NewAgentDataSource() datasource.DataSource {
+	return &AgentDataSource{}
+}
+
+type AgentDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *AgentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, AgentDataSourceName)
+}
+
+func (d *AgentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *AgentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", AgentDataSourceName),
+		Attributes:          agentDatasourceAttributes(),
+	}
+}
+
+func (d *AgentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AgentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	agents, _, err := d.Data.K.AgentAPI.ListAgents(ctx).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	for _, ag := range agents {
+		r, _, err := d.Data.K.AgentAPI.ReadAgent(ctx, ag).Execute()
+		if err != nil {
+			continue
+		}
+
+		if r.Name != data.Name.ValueString() {
+			continue
+		}
+
+		// optionally filter by agent type
+		if data.Type.ValueString() != "" && r.Type != data.Type.ValueString() {
+			continue
+		}
+
+		data.ID = types.StringPointerValue(r.Id)
+		if r.Description != nil {
+			data.Desc = types.StringPointerValue(r.Description)
+		} else {
+			data.Desc = types.StringValue("")
+		}
+		data.Type = types.StringValue(r.Type)
+		break
+	}
+
+	if data.ID.IsNull() {
+		resp.Diagnostics.AddError(ErrorGeneric, fmt.Sprintf("%s: %s", AgentDataSourceErrNotFound, data.Name.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -122,6 +122,9 @@ func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	data.ID = types.StringPointerValue(agent.Id)
 	agentModelToResource(agent, data) // read back resulting object
+	if !resourceCreatePending(ctx, resp, &data) {
+		return
+	}
 
 	// create a new authentication token
 	_, _, err = r.Data.K.AgentAPI.SetAgentApiToken(ctx, *agent.Id).Expire(false).Execute()
@@ -129,6 +132,7 @@ func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest,
 		errorCreateGeneric(resp, err)
 		return
 	}
+	resourceCreateCompleted(ctx, resp)
 
 	tflog.Trace(ctx, "created agent resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -140,6 +144,7 @@ func (r *AgentResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	DnsRecordDataSourceName = "dns_record"
+
+	DnsRecordDataSourceErrNotFound = "no such DNS record"
+)
+
+type DnsRecordDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Project   types.String `tfsdk:"project"`
+	Name      types.String `tfsdk:"name"`
+	Desc      types.String `tfsdk:"desc"`
+	Domain    types.String `tfsdk:"domain"`
+	Addresses types.List   `tfsdk:"addresses"`
+}
+
+func dnsRecordDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyProject: schema.StringAttribute{
+			MarkdownDescription: "Associated project name or ID",
+			Required:            true,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "DNS record name or ID",
+			Required:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: "DNS record description",
+			Computed:            true,
+		},
+		KeyDomain: schema.StringAttribute{
+			MarkdownDescription: "DNS domain the record belongs to",
+			Computed:            true,
+		},
+		KeyAddresses: schema.ListAttribute{
+			MarkdownDescription: "The list of IPv4 addresses associated with the DNS record",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+var _ datasource.DataSource = &DnsRecordDataSource{}
+var _ datasource.DataSourceWithConfigure = &DnsRecordDataSource{}
+
+func NewDnsRecordDataSource() datasource.DataSource {
+	return &DnsRecordDataSource{}
+}
+
+type DnsRecordDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *DnsRecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, DnsRecordDataSourceName)
+}
+
+func (d *DnsRecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *DnsRecordDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", DnsRecordDataSourceName),
+		Attributes:          dnsRecordDatasourceAttributes(),
+	}
+}
+
+func (d *DnsRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DnsRecordDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	projectId, err := getProjectID(ctx, d.Data, data.Project.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	records, _, err := d.Data.K.ProjectAPI.ListProjectDnsRecords(ctx, projectId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	for _, id := range records {
+		r, _, err := d.Data.K.RecordAPI.ReadDnsRecord(ctx, id).Execute()
+		if err != nil || (r.Name != data.Name.ValueString() && r.GetId() != data.Name.ValueString()) {
+			continue
+		}
+
+		data.ID = types.StringPointerValue(r.Id)
+		data.Desc = types.StringValue(r.GetDescription())
+		data.Domain = types.StringValue(r.GetDomain())
+		addresses, diags := types.ListValueFrom(ctx, types.StringType, r.Addresses)
+		resp.Diagnostics.Append(diags...)
+		data.Addresses = addresses
+		break
+	}
+
+	if data.ID.IsNull() {
+		resp.Diagnostics.AddError(ErrorGeneric, fmt.Sprintf("%s: %s", DnsRecordDataSourceErrNotFound, data.Name.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -8,14 +8,19 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"strings"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -25,6 +30,9 @@ const (
 
 	DnsRecordResourceErrTooFewArguments  = "either 'project' or 'region' field is required"
 	DnsRecordResourceErrTooManyArguments = "one can't ask for both 'project' and 'region' fields"
+	DnsRecordResourceErrAlreadyExists    = "DNS record '%s' already exists and is not managed by this resource, import it to take ownership"
+
+	DnsRecordPrivateKeyDomainConfigured = "domain_configured"
 )
 
 var _ resource.Resource = &DnsRecordResource{}
@@ -45,6 +53,7 @@ type DnsRecordResourceModel struct {
 	Desc      types.String   `tfsdk:"desc"`
 	Region    types.String   `tfsdk:"region"`
 	Project   types.String   `tfsdk:"project"`
+	Domain    types.String   `tfsdk:"domain"`
 	Addresses types.List     `tfsdk:"addresses"`
 }
 
@@ -72,6 +81,14 @@ func (r *DnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Associated region name or ID",
 				Optional:            true,
 			},
+			KeyDomain: schema.StringAttribute{
+				MarkdownDescription: "DNS domain the record belongs to, overriding project's or region's default one. Removing it reverts the record to the default domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					dnsRecordDomainModifier{},
+				},
+			},
 			KeyAddresses: schema.ListAttribute{
 				MarkdownDescription: "The list of IPv4 addresses to be associated with the DNS record",
 				ElementType:         types.StringType,
@@ -86,11 +103,15 @@ func (r *DnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 func recordResourceToModel(d *DnsRecordResourceModel) sdk.DnsRecord {
 	addresses := []string{}
 	d.Addresses.ElementsAs(context.TODO(), &addresses, false)
-	return sdk.DnsRecord{
+	record := sdk.DnsRecord{
 		Name:        d.Name.ValueString(),
 		Description: d.Desc.ValueStringPointer(),
 		Addresses:   addresses,
 	}
+	if !d.Domain.IsUnknown() && d.Domain.ValueString() != "" {
+		record.Domain = d.Domain.ValueStringPointer()
+	}
+	return record
 }
 
 // converts record from Kowabunga API model to Terraform model
@@ -101,6 +122,7 @@ func recordModelToResource(r *sdk.DnsRecord, d *DnsRecordResourceModel) {
 	} else {
 		d.Desc = types.StringValue("")
 	}
+	d.Domain = types.StringValue(r.GetDomain())
 	addresses := []attr.Value{}
 	for _, a := range r.Addresses {
 		addresses = append(addresses, types.StringValue(a))
@@ -108,6 +130,89 @@ func recordModelToResource(r *sdk.DnsRecord, d *DnsRecordResourceModel) {
 	d.Addresses, _ = types.ListValue(types.StringType, addresses)
 }
 
+// domain keeps its prior value unless it was explicitly configured and has
+// been removed from configuration since, in which case the record reverts to
+// the project's or region's default one
+type dnsRecordDomainModifier struct{}
+
+func (m dnsRecordDomainModifier) Description(ctx context.Context) string {
+	return "Keeps prior domain unless a configured one has been removed."
+}
+
+func (m dnsRecordDomainModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m dnsRecordDomainModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// nothing to keep on creation or destruction
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	configured, diags := req.Private.GetKey(ctx, DnsRecordPrivateKeyDomainConfigured)
+	resp.Diagnostics.Append(diags...)
+	if string(configured) == "true" {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// records whether domain has been explicitly configured, for the plan
+// modifier to tell a removed domain from a defaulted one
+func dnsRecordSetDomainConfigured(ctx context.Context, private privateState, config types.String) diag.Diagnostics {
+	if config.IsNull() {
+		return private.SetKey(ctx, DnsRecordPrivateKeyDomainConfigured, nil)
+	}
+	return private.SetKey(ctx, DnsRecordPrivateKeyDomainConfigured, []byte(`true`))
+}
+
+func dnsRecordFQDN(name, domain string) string {
+	return strings.ToLower(strings.TrimSuffix(name+"."+domain, "."))
+}
+
+// records are only ever managed through the ID they got created with, so
+// ensure not to take over one created by another controller (e.g. external-dns
+// like automation) with the very same name in the very same domain. Records of
+// a project or region are listed on each check, only the ones not yet in the
+// DNS records index being read, records without a domain being in parent's
+// default one.
+func (r *DnsRecordResource) checkOwnership(ctx context.Context, parentId, defaultDomain string, list func() ([]string, error), name, domain string) error {
+	if domain == "" {
+		domain = defaultDomain
+	}
+
+	records, err := list()
+	if err != nil {
+		return err
+	}
+	for _, id := range r.Data.DnsRecords.unknown(parentId, records) {
+		record, _, err := r.Data.K.RecordAPI.ReadDnsRecord(ctx, id).Execute()
+		if err != nil {
+			// left unknown, to be read again on next check
+			continue
+		}
+		r.setOwnership(parentId, defaultDomain, record)
+	}
+
+	if _, ok := r.Data.DnsRecords.owner(parentId, dnsRecordFQDN(name, domain)); ok {
+		return fmt.Errorf(DnsRecordResourceErrAlreadyExists, dnsRecordFQDN(name, domain))
+	}
+	return nil
+}
+
+// records a newly read or created record into the DNS records index
+func (r *DnsRecordResource) setOwnership(parentId, defaultDomain string, record *sdk.DnsRecord) {
+	domain := record.GetDomain()
+	if domain == "" {
+		domain = defaultDomain
+	}
+	r.Data.DnsRecords.set(parentId, record.GetId(), dnsRecordFQDN(record.Name, domain))
+}
+
 func (r *DnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *DnsRecordResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -146,14 +251,33 @@ func (r *DnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 			errorCreateGeneric(resp, err)
 			return
 		}
-		// create a new record
+		project, _, err := r.Data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+		if err != nil {
+			errorCreateGeneric(resp, err)
+			return
+		}
 		m := recordResourceToModel(data)
+		// ownership check and creation must not interleave with another
+		// record's of the same parent
+		unlock := r.Data.Locks.lock(DnsRecordResourceName, projectId)
+		defer unlock()
+		err = r.checkOwnership(ctx, projectId, project.GetDomain(), func() ([]string, error) {
+			records, _, err := r.Data.K.ProjectAPI.ListProjectDnsRecords(ctx, projectId).Execute()
+			return records, err
+		}, m.Name, m.GetDomain())
+		if err != nil {
+			errorCreateGeneric(resp, err)
+			return
+		}
+		// create a new record
 		record, _, err := r.Data.K.ProjectAPI.CreateProjectDnsRecord(ctx, projectId).DnsRecord(m).Execute()
 		if err != nil {
 			errorCreateGeneric(resp, err)
 			return
 		}
+		r.setOwnership(projectId, project.GetDomain(), record)
 		data.ID = types.StringPointerValue(record.Id)
+		data.Domain = types.StringValue(record.GetDomain())
 	}
 
 	// request by region
@@ -164,17 +288,37 @@ func (r *DnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 			errorCreateGeneric(resp, err)
 			return
 		}
-		// create a new record
+		region, _, err := r.Data.K.RegionAPI.ReadRegion(ctx, regionId).Execute()
+		if err != nil {
+			errorCreateGeneric(resp, err)
+			return
+		}
 		m := recordResourceToModel(data)
+		unlock := r.Data.Locks.lock(DnsRecordResourceName, regionId)
+		defer unlock()
+		err = r.checkOwnership(ctx, regionId, region.Domain, func() ([]string, error) {
+			records, _, err := r.Data.K.RegionAPI.ListRegionDnsRecords(ctx, regionId).Execute()
+			return records, err
+		}, m.Name, m.GetDomain())
+		if err != nil {
+			errorCreateGeneric(resp, err)
+			return
+		}
+		// create a new record
 		record, _, err := r.Data.K.RegionAPI.CreateRegionDnsRecord(ctx, regionId).DnsRecord(m).Execute()
 		if err != nil {
 			errorCreateGeneric(resp, err)
 			return
 		}
+		r.setOwnership(regionId, region.Domain, record)
 		data.ID = types.StringPointerValue(record.Id)
+		data.Domain = types.StringValue(record.GetDomain())
 	}
 
 	tflog.Trace(ctx, "created DNS record resource")
+	var domain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(KeyDomain), &domain)...)
+	resp.Diagnostics.Append(dnsRecordSetDomainConfigured(ctx, resp.Private, domain)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -226,11 +370,17 @@ func (r *DnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	defer r.Data.Mutex.Unlock()
 
 	m := recordResourceToModel(data)
-	_, _, err := r.Data.K.RecordAPI.UpdateDnsRecord(ctx, data.ID.ValueString()).DnsRecord(m).Execute()
+	record, _, err := r.Data.K.RecordAPI.UpdateDnsRecord(ctx, data.ID.ValueString()).DnsRecord(m).Execute()
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
 	}
+	data.Domain = types.StringValue(record.GetDomain())
+	r.Data.DnsRecords.forget(data.ID.ValueString())
+
+	var domain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(KeyDomain), &domain)...)
+	resp.Diagnostics.Append(dnsRecordSetDomainConfigured(ctx, resp.Private, domain)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -245,7 +395,7 @@ func (r *DnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	r.Data.Mutex.Lock()
@@ -256,5 +406,6 @@ func (r *DnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.DnsRecords.forget(data.ID.ValueString())
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
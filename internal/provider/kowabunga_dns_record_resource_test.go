@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// DNS record resource bound to a fake server which never answers, and a
+// record state with all operations timeouts set to a given value
+func testDnsRecordHanging(t *testing.T, timeout string) (*DnsRecordResource, tftypes.Value, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	done := make(chan struct{})
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		<-done
+	})
+	t.Cleanup(func() { close(done) }) // before server shutdown
+	r := &DnsRecordResource{Data: &KowabungaProviderData{K: k, Mutex: &sync.Mutex{}, IDs: newIDCache(IDCacheDefaultTTL), DnsRecords: newDnsRecordIndex(), Locks: newObjectLocks()}}
+	s := testResourceSchema(r)
+
+	objType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	timeoutsType := objType.AttributeTypes[KeyTimeouts].(tftypes.Object)
+	timeouts := map[string]tftypes.Value{}
+	for name := range timeoutsType.AttributeTypes {
+		timeouts[name] = tftypes.NewValue(tftypes.String, timeout)
+	}
+
+	value := testResourceValue(s, map[string]tftypes.Value{
+		KeyID:       tftypes.NewValue(tftypes.String, "r1"),
+		KeyName:     tftypes.NewValue(tftypes.String, "www"),
+		KeyProject:  tftypes.NewValue(tftypes.String, "p1"),
+		KeyDomain:   tftypes.NewValue(tftypes.String, "acme.com"),
+		KeyTimeouts: tftypes.NewValue(timeoutsType, timeouts),
+		KeyAddresses: tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "10.0.0.1"),
+		}),
+	})
+	return r, value, calls
+}
+
+// each CRUD operation is bound by its own timeout
+func TestDnsRecordResourceTimeouts(t *testing.T) {
+	ctx := context.Background()
+	r, value, _ := testDnsRecordHanging(t, "100ms")
+	s := testResourceSchema(r)
+
+	ops := map[string]func() bool{
+		"create": func() bool {
+			resp := resource.CreateResponse{State: tfsdk.State{Schema: s}}
+			r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: value}, Config: tfsdk.Config{Schema: s, Raw: value}}, &resp)
+			return resp.Diagnostics.HasError()
+		},
+		"read": func() bool {
+			resp := resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: value}}
+			r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: value}}, &resp)
+			return resp.Diagnostics.HasError()
+		},
+		"update": func() bool {
+			resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: value}}
+			r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan{Schema: s, Raw: value}, State: tfsdk.State{Schema: s, Raw: value}, Config: tfsdk.Config{Schema: s, Raw: value}}, &resp)
+			return resp.Diagnostics.HasError()
+		},
+		"delete": func() bool {
+			resp := resource.DeleteResponse{State: tfsdk.State{Schema: s, Raw: value}}
+			r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: value}}, &resp)
+			return resp.Diagnostics.HasError()
+		},
+	}
+	for op, fn := range ops {
+		t.Run(op, func(t *testing.T) {
+			start := time.Now()
+			if !fn() {
+				t.Error("expected a timeout error")
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("operation not bound by its timeout, took %s", elapsed)
+			}
+		})
+	}
+}
+
+// a cancelled operation doesn't reach the API
+func TestDnsRecordResourceDeleteCancelled(t *testing.T) {
+	r, value, calls := testDnsRecordHanging(t, "1m")
+	s := testResourceSchema(r)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp := resource.DeleteResponse{State: tfsdk.State{Schema: s, Raw: value}}
+	r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: value}}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected a cancellation error")
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("got %d API calls, want none", n)
+	}
+}
+
+// records created or deleted elsewhere are accounted for on each check,
+// known records only being read once
+func TestDnsRecordResourceCheckOwnership(t *testing.T) {
+	var mutex sync.Mutex
+	records := map[string]string{"r1": "www"}
+	reads := map[string]int{}
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		id, ok := strings.CutPrefix(req.URL.Path, "/api/v1/record/")
+		if !ok {
+			ids := []string{}
+			for id := range records {
+				ids = append(ids, `"`+id+`"`)
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(ids, ","))
+			return
+		}
+		reads[id]++
+		fmt.Fprintf(w, `{"id":%q,"name":%q,"addresses":[]}`, id, records[id])
+	})
+	r := &DnsRecordResource{Data: &KowabungaProviderData{K: k, Mutex: &sync.Mutex{}, IDs: newIDCache(IDCacheDefaultTTL), DnsRecords: newDnsRecordIndex(), Locks: newObjectLocks()}}
+
+	ctx := context.Background()
+	list := func() ([]string, error) {
+		ids, _, err := k.ProjectAPI.ListProjectDnsRecords(ctx, "p1").Execute()
+		return ids, err
+	}
+	check := func(name string) error {
+		return r.checkOwnership(ctx, "p1", "acme.com", list, name, "")
+	}
+
+	if err := check("www"); err == nil {
+		t.Error("www: expected an already existing record error")
+	}
+	if err := check("api"); err != nil {
+		t.Errorf("api: %v", err)
+	}
+
+	// created and deleted by another controller
+	mutex.Lock()
+	records["r2"] = "api"
+	delete(records, "r1")
+	mutex.Unlock()
+
+	if err := check("api"); err == nil {
+		t.Error("api: expected an already existing record error")
+	}
+	if err := check("www"); err != nil {
+		t.Errorf("www: %v", err)
+	}
+	if reads["r1"] != 1 || reads["r2"] != 1 {
+		t.Errorf("got %v reads, want each record read once", reads)
+	}
+
+	// renamed by this provider
+	mutex.Lock()
+	records["r2"] = "web"
+	mutex.Unlock()
+	r.Data.DnsRecords.forget("r2")
+	if err := check("api"); err != nil {
+		t.Errorf("api: %v", err)
+	}
+}
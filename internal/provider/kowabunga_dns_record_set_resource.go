@@ -0,0 +1,573 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DnsRecordSetResourceName = "dns_record_set"
+
+	// maximum number of concurrent DNS records API requests, when provider
+	// is configured as parallelism safe (sequential otherwise)
+	DnsRecordSetMaxConcurrentRequests = 8
+
+	DnsRecordSetResourceErrImportFormat    = "expected a %s:<name or ID>/<record names> or %s:<name or ID>/<record names> composite identifier, record names being comma-separated, got: %s"
+	DnsRecordSetResourceErrUnknownRecord   = "DNS record '%s' not found"
+	DnsRecordSetResourceErrAmbiguousRecord = "DNS record '%s' is ambiguous, more than one record goes by that name"
+)
+
+var _ resource.Resource = &DnsRecordSetResource{}
+var _ resource.ResourceWithImportState = &DnsRecordSetResource{}
+
+func NewDnsRecordSetResource() resource.Resource {
+	return &DnsRecordSetResource{}
+}
+
+type DnsRecordSetResource struct {
+	Data *KowabungaProviderData
+}
+
+type DnsRecordSetResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Desc      types.String   `tfsdk:"desc"`
+	Region    types.String   `tfsdk:"region"`
+	Project   types.String   `tfsdk:"project"`
+	Records   types.Map      `tfsdk:"records"`    // map[string][]string
+	RecordIDs types.Map      `tfsdk:"record_ids"` // map[string]string
+}
+
+func (r *DnsRecordSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resourceMetadata(req, resp, DnsRecordSetResourceName)
+}
+
+// imports a record set from its parent and records names, e.g.
+// project:acme/www,api or region:eu-west/ntp
+func (r *DnsRecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parentKey, parent, names, err := recordSetParseImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(ErrorImport, err.Error())
+		return
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	var parentId string
+	var records []string
+	if parentKey == KeyProject {
+		parentId, err = getProjectID(ctx, r.Data, parent)
+		if err == nil {
+			records, _, err = r.Data.K.ProjectAPI.ListProjectDnsRecords(ctx, parentId).Execute()
+		}
+	} else {
+		parentId, err = getRegionID(ctx, r.Data, parent)
+		if err == nil {
+			records, _, err = r.Data.K.RegionAPI.ListRegionDnsRecords(ctx, parentId).Execute()
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(ErrorImport, err.Error())
+		return
+	}
+
+	read, err := r.readRecords(ctx, records)
+	if err != nil {
+		resp.Diagnostics.AddError(ErrorImport, err.Error())
+		return
+	}
+	ids, err := recordSetImportIDs(read, names)
+	if err != nil {
+		resp.Diagnostics.AddError(ErrorImport, err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(parentKey), parent)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KeyID), recordSetID(parentId, names))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KeyRecordIDs), ids)...)
+}
+
+func (r *DnsRecordSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.Data = resourceConfigure(req, resp)
+}
+
+func (r *DnsRecordSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of DNS records as a single resource. Records are created, read, updated and deleted in batch, which is better suited than individual **dns_record** resources for services with many records. Existing records can be imported as a set with a `project:<name or ID>/<record names>` or `region:<name or ID>/<record names>` identifier, record names being comma-separated.",
+		Attributes: map[string]schema.Attribute{
+			KeyProject: schema.StringAttribute{
+				MarkdownDescription: "Associated project name or ID",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			KeyRegion: schema.StringAttribute{
+				MarkdownDescription: "Associated region name or ID",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			KeyRecords: schema.MapAttribute{
+				MarkdownDescription: "The DNS records, as a map of record name to its list of IPv4 addresses",
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				Required: true,
+			},
+			KeyRecordIDs: schema.MapAttribute{
+				MarkdownDescription: "The DNS records identifiers, as a map of record name to record ID (read-only)",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, resourceAttributesWithoutName(&ctx))
+}
+
+// converts record set from Terraform model to Kowabunga API models
+func recordSetResourceToModel(ctx context.Context, d *DnsRecordSetResourceModel) map[string]sdk.DnsRecord {
+	records := map[string][]string{}
+	diags := d.Records.ElementsAs(ctx, &records, false)
+	if diags.HasError() {
+		for _, err := range diags.Errors() {
+			tflog.Debug(ctx, err.Detail())
+		}
+	}
+
+	models := map[string]sdk.DnsRecord{}
+	for name, addresses := range records {
+		models[name] = sdk.DnsRecord{
+			Name:        name,
+			Description: d.Desc.ValueStringPointer(),
+			Addresses:   addresses,
+		}
+	}
+	return models
+}
+
+// converts record set from Kowabunga API models to Terraform model
+func recordSetModelToResource(ctx context.Context, r map[string]*sdk.DnsRecord, d *DnsRecordSetResourceModel) {
+	records := map[string][]string{}
+	ids := map[string]string{}
+	for _, rec := range r {
+		records[rec.Name] = rec.Addresses
+		ids[rec.Name] = rec.GetId()
+		if rec.Description != nil {
+			d.Desc = types.StringPointerValue(rec.Description)
+		}
+	}
+
+	var diags, idsDiags diag.Diagnostics
+	d.Records, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, records)
+	d.RecordIDs, idsDiags = types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(idsDiags...)
+	if diags.HasError() {
+		for _, err := range diags.Errors() {
+			tflog.Debug(ctx, err.Detail())
+		}
+	}
+}
+
+// returns the record set's known record IDs, indexed by record name
+func recordSetIDs(ctx context.Context, d *DnsRecordSetResourceModel) map[string]string {
+	ids := map[string]string{}
+	if d.RecordIDs.IsNull() || d.RecordIDs.IsUnknown() {
+		return ids
+	}
+	diags := d.RecordIDs.ElementsAs(ctx, &ids, false)
+	if diags.HasError() {
+		for _, err := range diags.Errors() {
+			tflog.Debug(ctx, err.Detail())
+		}
+	}
+	return ids
+}
+
+// returns a record set ID, unique amongst its parent ones, from its parent
+// ID and records names at creation time, e.g. <project ID>/2c26b46b68ffc68f
+func recordSetID(parentId string, names []string) string {
+	names = slices.Sorted(slices.Values(names))
+	sum := sha256.Sum256([]byte(strings.Join(names, ",")))
+	return parentId + "/" + hex.EncodeToString(sum[:8])
+}
+
+// parses a record set import identifier, returns its parent key (i.e. project
+// or region), parent name or ID and records names
+func recordSetParseImportID(id string) (string, string, []string, error) {
+	errFormat := fmt.Errorf(DnsRecordSetResourceErrImportFormat, KeyProject, KeyRegion, id)
+
+	parentKey, rest, ok := strings.Cut(id, ":")
+	if !ok || (parentKey != KeyProject && parentKey != KeyRegion) {
+		return "", "", nil, errFormat
+	}
+	parent, list, ok := strings.Cut(rest, "/")
+	if !ok || parent == "" || list == "" {
+		return "", "", nil, errFormat
+	}
+	names := strings.Split(list, ",")
+	if slices.Contains(names, "") {
+		return "", "", nil, errFormat
+	}
+	return parentKey, parent, names, nil
+}
+
+// returns the IDs of the named records, indexed by record name, amongst all
+// of parent's records
+func recordSetImportIDs(records map[string]*sdk.DnsRecord, names []string) (map[string]string, error) {
+	ids := map[string]string{}
+	for _, rec := range records {
+		if !slices.Contains(names, rec.Name) {
+			continue
+		}
+		if _, ok := ids[rec.Name]; ok {
+			return nil, fmt.Errorf(DnsRecordSetResourceErrAmbiguousRecord, rec.Name)
+		}
+		ids[rec.Name] = rec.GetId()
+	}
+	for _, name := range names {
+		if _, ok := ids[name]; !ok {
+			return nil, fmt.Errorf(DnsRecordSetResourceErrUnknownRecord, name)
+		}
+	}
+	return ids, nil
+}
+
+// returns the record set parent project or region ID
+func (r *DnsRecordSetResource) parentID(ctx context.Context, d *DnsRecordSetResourceModel) (string, error) {
+	if d.Project.ValueString() != "" {
+		return getProjectID(ctx, r.Data, d.Project.ValueString())
+	}
+	return getRegionID(ctx, r.Data, d.Region.ValueString())
+}
+
+// returns the number of concurrent API requests a batch may issue, API calls
+// being serialized unless provider is configured as parallelism safe
+func (r *DnsRecordSetResource) concurrency() int {
+	if r.Data.ParallelSafe {
+		return DnsRecordSetMaxConcurrentRequests
+	}
+	return 1
+}
+
+// runs fn over all keys, with up to concurrency parallel calls, returns
+// first encountered error
+func recordSetBatch(concurrency int, keys []string, fn func(key string) error) error {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var firstErr error
+
+	sem := make(chan struct{}, concurrency)
+	for _, k := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := fn(key)
+			if err != nil {
+				mutex.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mutex.Unlock()
+			}
+		}(k)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// reads all records from their IDs, in batch, records removed out of
+// Terraform being skipped
+func (r *DnsRecordSetResource) readRecords(ctx context.Context, ids []string) (map[string]*sdk.DnsRecord, error) {
+	var mutex sync.Mutex
+	records := map[string]*sdk.DnsRecord{}
+
+	err := recordSetBatch(r.concurrency(), ids, func(id string) error {
+		record, httpResp, err := r.Data.K.RecordAPI.ReadDnsRecord(ctx, id).Execute()
+		if errorIsNotFound(httpResp, err) {
+			tflog.Warn(ctx, "DNS record no longer exists, removing from state", map[string]any{
+				"id": id,
+			})
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		mutex.Lock()
+		records[id] = record
+		mutex.Unlock()
+		return nil
+	})
+
+	return records, err
+}
+
+// creates records into parent project or region, in batch, successfully
+// created ones being added to records
+func (r *DnsRecordSetResource) createRecords(ctx context.Context, data *DnsRecordSetResourceModel, parentId string, models map[string]sdk.DnsRecord, records map[string]*sdk.DnsRecord) error {
+	var mutex sync.Mutex
+
+	return recordSetBatch(r.concurrency(), slices.Collect(maps.Keys(models)), func(name string) error {
+		var record *sdk.DnsRecord
+		var err error
+		if data.Project.ValueString() != "" {
+			record, _, err = r.Data.K.ProjectAPI.CreateProjectDnsRecord(ctx, parentId).DnsRecord(models[name]).Execute()
+		} else {
+			record, _, err = r.Data.K.RegionAPI.CreateRegionDnsRecord(ctx, parentId).DnsRecord(models[name]).Execute()
+		}
+		if err != nil {
+			return err
+		}
+		mutex.Lock()
+		records[record.GetId()] = record
+		mutex.Unlock()
+		return nil
+	})
+}
+
+func (r *DnsRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DnsRecordSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	// check that at least one argument has been passed over
+	if data.Project.ValueString() == "" && data.Region.ValueString() == "" {
+		resp.Diagnostics.AddError(ErrorGeneric, DnsRecordResourceErrTooFewArguments)
+		return
+	}
+
+	// check that no all arguments has been passed over
+	if data.Project.ValueString() != "" && data.Region.ValueString() != "" {
+		resp.Diagnostics.AddError(ErrorGeneric, DnsRecordResourceErrTooManyArguments)
+		return
+	}
+
+	// find parent project or region
+	parentId, err := r.parentID(ctx, data)
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+
+	// create all records
+	models := recordSetResourceToModel(ctx, data)
+	data.ID = types.StringValue(recordSetID(parentId, slices.Collect(maps.Keys(models))))
+	records := map[string]*sdk.DnsRecord{}
+	err = r.createRecords(ctx, data, parentId, models, records)
+	recordSetModelToResource(ctx, records, data) // read back resulting objects, even partially
+	if err != nil {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		errorCreateGeneric(resp, err)
+		return
+	}
+
+	tflog.Trace(ctx, "created DNS record set resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DnsRecordSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	ids := slices.Collect(maps.Values(recordSetIDs(ctx, data)))
+	records, err := r.readRecords(ctx, ids)
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+
+	recordSetModelToResource(ctx, records, data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *DnsRecordSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	parentId, err := r.parentID(ctx, data)
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+
+	models := recordSetResourceToModel(ctx, data)
+	prior := recordSetResourceToModel(ctx, state)
+	ids := recordSetIDs(ctx, state)
+
+	// records known to exist, as per prior state, kept up to date with each
+	// API call outcome so that partial changes can be saved on failure
+	var mutex sync.Mutex
+	records := map[string]*sdk.DnsRecord{}
+	for name, id := range ids {
+		m := prior[name]
+		m.Id = &id
+		records[id] = &m
+	}
+	failed := func(err error) {
+		recordSetModelToResource(ctx, records, data)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		errorUpdateGeneric(resp, err)
+	}
+
+	// sort out records to be created, updated or deleted
+	created := map[string]sdk.DnsRecord{}
+	updated := []string{}
+	deleted := []string{}
+	for name, m := range models {
+		_, ok := ids[name]
+		if !ok {
+			created[name] = m
+			continue
+		}
+		if !slices.Equal(m.Addresses, prior[name].Addresses) || data.Desc.ValueString() != state.Desc.ValueString() {
+			updated = append(updated, name)
+		}
+	}
+	for name := range ids {
+		if _, ok := models[name]; !ok {
+			deleted = append(deleted, name)
+		}
+	}
+
+	err = recordSetBatch(r.concurrency(), deleted, func(name string) error {
+		httpResp, err := r.Data.K.RecordAPI.DeleteDnsRecord(ctx, ids[name]).Execute()
+		if err != nil && !errorIsNotFound(httpResp, err) {
+			return err
+		}
+		mutex.Lock()
+		delete(records, ids[name])
+		mutex.Unlock()
+		return nil
+	})
+	if err != nil {
+		failed(err)
+		return
+	}
+
+	err = recordSetBatch(r.concurrency(), updated, func(name string) error {
+		record, _, err := r.Data.K.RecordAPI.UpdateDnsRecord(ctx, ids[name]).DnsRecord(models[name]).Execute()
+		if err != nil {
+			return err
+		}
+		mutex.Lock()
+		records[ids[name]] = record
+		mutex.Unlock()
+		return nil
+	})
+	if err != nil {
+		failed(err)
+		return
+	}
+
+	err = r.createRecords(ctx, data, parentId, created, records)
+	if err != nil {
+		failed(err)
+		return
+	}
+
+	recordSetModelToResource(ctx, records, data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DnsRecordSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := data.Timeouts.Delete(ctx, DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	ids := recordSetIDs(ctx, data)
+	err := recordSetBatch(r.concurrency(), slices.Collect(maps.Values(ids)), func(id string) error {
+		httpResp, err := r.Data.K.RecordAPI.DeleteDnsRecord(ctx, id).Execute()
+		if errorIsNotFound(httpResp, err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+)
+
+// all keys are processed, never more than concurrency at once, and first
+// error is returned
+func TestRecordSetBatch(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprint(concurrency), func(t *testing.T) {
+			var mutex sync.Mutex
+			var running, peak atomic.Int32
+			done := []string{}
+			err := recordSetBatch(concurrency, keys, func(key string) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				mutex.Lock()
+				done = append(done, key)
+				mutex.Unlock()
+				if key == "c" {
+					return fmt.Errorf("failed %s", key)
+				}
+				return nil
+			})
+			if err == nil || err.Error() != "failed c" {
+				t.Errorf("got error %v, want failed c", err)
+			}
+			slices.Sort(done)
+			if !slices.Equal(done, keys) {
+				t.Errorf("got %v processed, want %v", done, keys)
+			}
+			if p := peak.Load(); p > int32(concurrency) {
+				t.Errorf("got %d concurrent calls, want at most %d", p, concurrency)
+			}
+		})
+	}
+
+	if err := recordSetBatch(1, nil, func(key string) error { return fmt.Errorf("unexpected") }); err != nil {
+		t.Errorf("empty batch: %v", err)
+	}
+}
+
+// record set ID is scoped by parent, whatever the records order
+func TestRecordSetID(t *testing.T) {
+	id := recordSetID("p1", []string{"www", "api"})
+	if !strings.HasPrefix(id, "p1/") || len(id) != len("p1/")+16 {
+		t.Errorf("unexpected ID format: %s", id)
+	}
+	if other := recordSetID("p1", []string{"api", "www"}); other != id {
+		t.Errorf("records order changed ID: %s vs %s", other, id)
+	}
+	if other := recordSetID("p2", []string{"www", "api"}); other == id {
+		t.Errorf("different parents, same ID: %s", id)
+	}
+	if other := recordSetID("p1", []string{"www"}); other == id {
+		t.Errorf("different records, same ID: %s", id)
+	}
+}
+
+func TestRecordSetParseImportID(t *testing.T) {
+	tests := []struct {
+		id        string
+		parentKey string
+		parent    string
+		names     []string
+	}{
+		{id: "project:acme/www,api", parentKey: KeyProject, parent: "acme", names: []string{"www", "api"}},
+		{id: "region:eu-west/ntp", parentKey: KeyRegion, parent: "eu-west", names: []string{"ntp"}},
+		{id: "acme/www"},
+		{id: "zone:z1/www"},
+		{id: "project:/www"},
+		{id: "project:acme/"},
+		{id: "project:acme"},
+		{id: "project:acme/www,,api"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			parentKey, parent, names, err := recordSetParseImportID(tc.id)
+			if tc.parentKey == "" {
+				if err == nil {
+					t.Error("expected an import format error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parentKey != tc.parentKey || parent != tc.parent || !slices.Equal(names, tc.names) {
+				t.Errorf("got %s:%s/%v, want %s:%s/%v", parentKey, parent, names, tc.parentKey, tc.parent, tc.names)
+			}
+		})
+	}
+}
+
+func TestRecordSetImportIDs(t *testing.T) {
+	record := func(id, name string) *sdk.DnsRecord {
+		return &sdk.DnsRecord{Id: &id, Name: name}
+	}
+	records := map[string]*sdk.DnsRecord{
+		"r1": record("r1", "www"),
+		"r2": record("r2", "api"),
+		"r3": record("r3", "mail"),
+		"r4": record("r4", "mail"),
+	}
+
+	ids, err := recordSetImportIDs(records, []string{"www", "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"www": "r1", "api": "r2"}; !maps.Equal(ids, want) {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+
+	_, err = recordSetImportIDs(records, []string{"www", "ntp"})
+	if want := fmt.Sprintf(DnsRecordSetResourceErrUnknownRecord, "ntp"); err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+	_, err = recordSetImportIDs(records, []string{"mail"})
+	if want := fmt.Sprintf(DnsRecordSetResourceErrAmbiguousRecord, "mail"); err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	DnsRecordsDataSourceName = "dns_records"
+)
+
+var _ datasource.DataSource = &DnsRecordsDataSource{}
+var _ datasource.DataSourceWithConfigure = &DnsRecordsDataSource{}
+
+func NewDnsRecordsDataSource() datasource.DataSource {
+	return &DnsRecordsDataSource{}
+}
+
+type DnsRecordsDataSource struct {
+	Data *KowabungaProviderData
+}
+
+type DnsRecordsDataSourceModel struct {
+	Project    types.String            `tfsdk:"project"`
+	Prefix     types.String            `tfsdk:"prefix"`
+	DnsRecords map[string]types.String `tfsdk:"dns_records"`
+}
+
+func (d *DnsRecordsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, DnsRecordsDataSourceName)
+}
+
+func (d *DnsRecordsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *DnsRecordsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a project's %s", DnsRecordsDataSourceName),
+		Attributes: map[string]schema.Attribute{
+			KeyProject: schema.StringAttribute{
+				MarkdownDescription: "Associated project name or ID",
+				Required:            true,
+			},
+			KeyPrefix: schema.StringAttribute{
+				MarkdownDescription: "Only list DNS records whose name starts with this prefix",
+				Optional:            true,
+			},
+			DnsRecordsDataSourceName: schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("Map of Kowabunga %s IDs, keyed by lowercased FQDN (records without a domain being in project's default one)", DnsRecordsDataSourceName),
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// indexes records by FQDN, as the same name can be used across domains
+func dnsRecordsByFQDN(records []*sdk.DnsRecord, defaultDomain, prefix string) map[string]types.String {
+	fqdns := map[string]types.String{}
+	for _, r := range records {
+		if !strings.HasPrefix(r.Name, prefix) {
+			continue
+		}
+		domain := r.GetDomain()
+		if domain == "" {
+			domain = defaultDomain
+		}
+		fqdns[dnsRecordFQDN(r.Name, domain)] = types.StringPointerValue(r.Id)
+	}
+	return fqdns
+}
+
+func (d *DnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DnsRecordsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	projectId, err := getProjectID(ctx, d.Data, data.Project.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	project, _, err := d.Data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	ids, _, err := d.Data.K.ProjectAPI.ListProjectDnsRecords(ctx, projectId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	records := []*sdk.DnsRecord{}
+	for _, id := range ids {
+		r, _, err := d.Data.K.RecordAPI.ReadDnsRecord(ctx, id).Execute()
+		if err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	data.DnsRecords = dnsRecordsByFQDN(records, project.GetDomain(), data.Prefix.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+)
+
+func TestDnsRecordsByFQDN(t *testing.T) {
+	record := func(id, name, domain string) *sdk.DnsRecord {
+		r := &sdk.DnsRecord{Id: &id, Name: name}
+		if domain != "" {
+			r.Domain = &domain
+		}
+		return r
+	}
+	records := []*sdk.DnsRecord{
+		record("r1", "www", ""),
+		record("r2", "www", "acme.com"),
+		record("r3", "WWW", "Other.org"),
+		record("r4", "mail", "acme.com"),
+	}
+
+	got := dnsRecordsByFQDN(records, "prj.acme.com", "WWW")
+	if keys := slices.Sorted(maps.Keys(got)); !slices.Equal(keys, []string{"www.other.org"}) {
+		t.Errorf("prefix filtered records: got %v", keys)
+	}
+
+	got = dnsRecordsByFQDN(records, "prj.acme.com", "")
+	want := map[string]string{
+		"www.prj.acme.com": "r1",
+		"www.acme.com":     "r2",
+		"www.other.org":    "r3",
+		"mail.acme.com":    "r4",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for fqdn, id := range want {
+		if got[fqdn].ValueString() != id {
+			t.Errorf("%s: got %s, want %s", fqdn, got[fqdn], id)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	EnumsDataSourceName              = "enums"
+	EnumsDataSourceSchemaDescription = "Valid values of provider's enumerated attributes, as enforced by its validators. Allows modules to build their own validation without hardcoding values that may drift from the provider."
+)
+
+var _ datasource.DataSource = &EnumsDataSource{}
+var _ datasource.DataSourceWithConfigure = &EnumsDataSource{}
+
+func NewEnumsDataSource() datasource.DataSource {
+	return &EnumsDataSource{}
+}
+
+type EnumsDataSource struct {
+	Data *KowabungaProviderData
+}
+
+type EnumsDataSourceModel struct {
+	AgentTypes        []types.String `tfsdk:"agent_types"`
+	UserRoles         []types.String `tfsdk:"user_roles"`
+	VolumeTypes       []types.String `tfsdk:"volume_types"`
+	TemplateOS        []types.String `tfsdk:"template_os"`
+	NetworkProtocols  []types.String `tfsdk:"network_protocols"`
+	PortlessProtocols []types.String `tfsdk:"portless_protocols"`
+	FirewallPolicies  []types.String `tfsdk:"firewall_policies"`
+	KyloAccessTypes   []types.String `tfsdk:"kylo_access_types"`
+	KyloProtocols     []types.Int64  `tfsdk:"kylo_protocols"`
+	IPsecEncryptions  []types.String `tfsdk:"ipsec_encryption_algorithms"`
+	IPsecIntegrities  []types.String `tfsdk:"ipsec_integrity_algorithms"`
+	IPsecDHGroups     []types.Int64  `tfsdk:"ipsec_dh_groups"`
+	IPsecStartActions []types.String `tfsdk:"ipsec_start_actions"`
+	IPsecDpdActions   []types.String `tfsdk:"ipsec_dpd_actions"`
+	PowerStates       []types.String `tfsdk:"power_states"`
+}
+
+func enumsDatasourceStringsAttribute(desc string) schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: desc + " (read-only)",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+}
+
+func enumsDatasourceInt64sAttribute(desc string) schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: desc + " (read-only)",
+		ElementType:         types.Int64Type,
+		Computed:            true,
+	}
+}
+
+func enumsStrings(values []string) []types.String {
+	res := []types.String{}
+	for _, v := range values {
+		res = append(res, types.StringValue(v))
+	}
+	return res
+}
+
+func enumsInt64s(values []int64) []types.Int64 {
+	res := []types.Int64{}
+	for _, v := range values {
+		res = append(res, types.Int64Value(v))
+	}
+	return res
+}
+
+func (d *EnumsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, EnumsDataSourceName)
+}
+
+func (d *EnumsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *EnumsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: EnumsDataSourceSchemaDescription,
+		Attributes: map[string]schema.Attribute{
+			KeyAgentTypes:                enumsDatasourceStringsAttribute("Remote agent types"),
+			KeyUserRoles:                 enumsDatasourceStringsAttribute("User roles"),
+			KeyVolumeTypes:               enumsDatasourceStringsAttribute("Storage volume types"),
+			KeyTemplateOS:                enumsDatasourceStringsAttribute("Volume template operating systems"),
+			KeyNetworkProtocols:          enumsDatasourceStringsAttribute("Transport layer protocols"),
+			KeyPortlessProtocols:         enumsDatasourceStringsAttribute("Portless VPN and tunneling protocols, supported by Kawaii firewall rules"),
+			KeyFirewallPolicies:          enumsDatasourceStringsAttribute("Firewall policies"),
+			KeyKyloAccessTypes:           enumsDatasourceStringsAttribute("Kylo NFS access types"),
+			KeyKyloProtocols:             enumsDatasourceInt64sAttribute("Kylo NFS protocol versions"),
+			KeyIPsecEncryptionAlgorithms: enumsDatasourceStringsAttribute("IPsec encryption algorithms"),
+			KeyIPsecIntegrityAlgorithms:  enumsDatasourceStringsAttribute("IPsec integrity algorithms"),
+			KeyIPsecDHGroups:             enumsDatasourceInt64sAttribute("IPsec Diffie-Hellman groups"),
+			KeyIPsecStartActions:         enumsDatasourceStringsAttribute("IPsec start actions"),
+			KeyIPsecDpdActions:           enumsDatasourceStringsAttribute("IPsec dead peer detection actions"),
+			KeyPowerStates:               enumsDatasourceStringsAttribute("Kompute and instance power states"),
+		},
+	}
+}
+
+func (d *EnumsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := EnumsDataSourceModel{
+		AgentTypes:        enumsStrings(agentSupportedTypes),
+		UserRoles:         enumsStrings(userSupportedRoles),
+		VolumeTypes:       enumsStrings(volumeSupportedTypes),
+		TemplateOS:        enumsStrings(templateSupportedOS),
+		NetworkProtocols:  enumsStrings(networkSupportedProtocols),
+		PortlessProtocols: enumsStrings(networkPortlessProtocols),
+		FirewallPolicies:  enumsStrings(firewallSupportedPolicy),
+		KyloAccessTypes:   enumsStrings(kyloSupportedAccessTypes),
+		KyloProtocols:     enumsInt64s(kyloSupportedProtocols),
+		IPsecEncryptions:  enumsStrings(encryptionSupportedTypes),
+		IPsecIntegrities:  enumsStrings(integritySupportedTypes),
+		IPsecDHGroups:     enumsInt64s(diffieHellmanSupportedTypes),
+		IPsecStartActions: enumsStrings(ipsecSupportedStartActions),
+		IPsecDpdActions:   enumsStrings(ipsecSupportedDpdActions),
+		PowerStates:       enumsStrings(powerSupportedStates),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
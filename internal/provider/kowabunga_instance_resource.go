@@ -9,24 +9,34 @@ package provider
 import (
 	"context"
 	"maps"
+	"net/http"
 	"sort"
+	"time"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	InstanceResourceName = "instance"
+
+	InstanceDefaultValueShutdownTimeout = 0
 )
 
 var _ resource.Resource = &InstanceResource{}
 var _ resource.ResourceWithImportState = &InstanceResource{}
+var _ resource.ResourceWithValidateConfig = &InstanceResource{}
 
 func NewInstanceResource() resource.Resource {
 	return &InstanceResource{}
@@ -44,9 +54,13 @@ type InstanceResourceModel struct {
 	Project  types.String   `tfsdk:"project"`
 	Zone     types.String   `tfsdk:"zone"`
 	VCPUs    types.Int64    `tfsdk:"vcpus"`
-	Memory   types.Int64    `tfsdk:"mem"`
+	Memory   SizeGBValue    `tfsdk:"mem"`
 	Adapters types.List     `tfsdk:"adapters"`
 	Volumes  types.List     `tfsdk:"volumes"`
+	State    types.String   `tfsdk:"state"`
+	Reboot   types.String   `tfsdk:"reboot_trigger"`
+	Shutdown types.Int64    `tfsdk:"shutdown_timeout"`
+	MACs     types.List     `tfsdk:"macs"` // read-only
 }
 
 func (r *InstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,6 +94,7 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 			KeyMemory: schema.Int64Attribute{
 				MarkdownDescription: "The instance memory size (expressed in GB)",
 				Required:            true,
+				CustomType:          SizeGBType{},
 			},
 			KeyAdapters: schema.ListAttribute{
 				MarkdownDescription: "The list of network adapters to be associated with the instance",
@@ -91,6 +106,25 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				ElementType:         types.StringType,
 				Required:            true,
 			},
+			KeyState:         resourcePowerStateAttribute("Instance"),
+			KeyRebootTrigger: resourceRebootTriggerAttribute("Instance"),
+			KeyShutdownTimeout: schema.Int64Attribute{
+				MarkdownDescription: "Graceful shutdown timeout (expressed in seconds, disabled by default, 0 to disable). When set, a running instance being deleted or changed to `stopped` state is first asked to shut down cleanly (ACPI) and given this time to power off, before being forced off. Must be lower than delete and update timeouts.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(InstanceDefaultValueShutdownTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			KeyMACs: schema.ListAttribute{
+				MarkdownDescription: "The list of network adapters MAC addresses, in the instance's adapters (PCI) order (read-only)",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listUseStateForUnknownUnlessChanged(path.Root(KeyAdapters)),
+				},
+			},
 		},
 	}
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
@@ -98,7 +132,7 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 
 // converts instance from Terraform model to Kowabunga API model
 func instanceResourceToModel(d *InstanceResourceModel) sdk.Instance {
-	memSize := d.Memory.ValueInt64() * HelperGbToBytes
+	memSize := d.Memory.ValueBytes()
 	adapters := []string{}
 	d.Adapters.ElementsAs(context.TODO(), &adapters, false)
 	volumes := []string{}
@@ -121,7 +155,6 @@ func instanceModelToResource(r *sdk.Instance, d *InstanceResourceModel) {
 		return
 	}
 
-	memSize := r.Memory / HelperGbToBytes
 	d.Name = types.StringValue(r.Name)
 	if r.Description != nil {
 		d.Desc = types.StringPointerValue(r.Description)
@@ -129,7 +162,7 @@ func instanceModelToResource(r *sdk.Instance, d *InstanceResourceModel) {
 		d.Desc = types.StringValue("")
 	}
 	d.VCPUs = types.Int64Value(r.Vcpus)
-	d.Memory = types.Int64Value(memSize)
+	d.Memory = NewSizeGBValueFromBytes(r.Memory)
 	adapters := []attr.Value{}
 	for _, a := range r.Adapters {
 		adapters = append(adapters, types.StringValue(a))
@@ -143,6 +176,47 @@ func instanceModelToResource(r *sdk.Instance, d *InstanceResourceModel) {
 	d.Volumes, _ = types.ListValue(types.StringType, volumes)
 }
 
+// retrieves instance's network adapters MAC addresses, in adapters order
+func instanceAdaptersMACs(ctx context.Context, data *KowabungaProviderData, r *sdk.Instance, d *InstanceResourceModel) error {
+	if r == nil {
+		return nil
+	}
+
+	macs := []attr.Value{}
+	for _, a := range r.Adapters {
+		adapter, _, err := data.K.AdapterAPI.ReadAdapter(ctx, a).Execute()
+		if err != nil {
+			return err
+		}
+		macs = append(macs, types.StringValue(adapter.GetMac()))
+	}
+	d.MACs, _ = types.ListValue(types.StringType, macs)
+	return nil
+}
+
+func (r *InstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data InstanceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resourceValidateWait(ctx, data.Timeouts, "delete", data.Shutdown, KeyShutdownTimeout, &resp.Diagnostics)
+	resourceValidateWait(ctx, data.Timeouts, "update", data.Shutdown, KeyShutdownTimeout, &resp.Diagnostics)
+}
+
+func (r *InstanceResource) powerActions(ctx context.Context, id string, shutdown types.Int64) powerActions {
+	return powerActions{
+		Start:    r.Data.K.InstanceAPI.StartInstance(ctx, id).Execute,
+		Stop:     r.Data.K.InstanceAPI.StopInstance(ctx, id).Execute,
+		Reboot:   r.Data.K.InstanceAPI.RebootInstance(ctx, id).Execute,
+		Shutdown: r.Data.K.InstanceAPI.ShutdownInstance(ctx, id).Execute,
+		State:    r.Data.K.InstanceAPI.ReadInstanceState(ctx, id).Execute,
+
+		Mutex:           r.Data.Mutex,
+		ShutdownTimeout: time.Duration(shutdown.ValueInt64()) * time.Second,
+	}
+}
+
 func (r *InstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *InstanceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -182,6 +256,24 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	data.ID = types.StringPointerValue(instance.Id)
 	instanceModelToResource(instance, data) // read back resulting object
+	err = instanceAdaptersMACs(ctx, r.Data, instance, data)
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+
+	// newly created instance is running
+	if data.State.ValueString() == PowerStateStopped {
+		if !resourceCreatePending(ctx, resp, &data) {
+			return
+		}
+		err = powerStateApply(ctx, PowerStateRunning, PowerStateStopped, r.powerActions(ctx, *instance.Id, types.Int64Value(0)))
+		if err != nil {
+			errorCreateGeneric(resp, err)
+			return
+		}
+		resourceCreateCompleted(ctx, resp)
+	}
 	tflog.Trace(ctx, "created instance resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -192,6 +284,7 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
@@ -209,7 +302,27 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		errorReadGeneric(resp, err)
 		return
 	}
+
+	// resolve parent references, unknown after import
+	if data.Project.IsNull() || data.Zone.IsNull() {
+		project, zone, err := getProjectZoneParents(ctx, r.Data, data.ID.ValueString(), func(projectId, zoneId string) ([]string, *http.Response, error) {
+			return r.Data.K.ProjectAPI.ListProjectZoneInstances(ctx, projectId, zoneId).Execute()
+		})
+		if err != nil {
+			errorReadGeneric(resp, err)
+			return
+		}
+		data.Project = types.StringValue(project)
+		data.Zone = types.StringValue(zone)
+	}
 	instanceModelToResource(instance, data)
+	err = instanceAdaptersMACs(ctx, r.Data, instance, data)
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+
+	data.State = types.StringValue(powerStateRead(ctx, r.powerActions(ctx, data.ID.ValueString(), data.Shutdown), data.State.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -232,7 +345,30 @@ func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateReques
 	defer r.Data.Mutex.Unlock()
 
 	m := instanceResourceToModel(data)
-	_, _, err := r.Data.K.InstanceAPI.UpdateInstance(ctx, data.ID.ValueString()).Instance(m).Execute()
+	instance, _, err := r.Data.K.InstanceAPI.UpdateInstance(ctx, data.ID.ValueString()).Instance(m).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	err = instanceAdaptersMACs(ctx, r.Data, instance, data)
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+
+	var current, trigger types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(KeyState), &current)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(KeyRebootTrigger), &trigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	actions := r.powerActions(ctx, data.ID.ValueString(), data.Shutdown)
+	err = powerStateApply(ctx, current.ValueString(), data.State.ValueString(), actions)
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	err = powerRebootOnTrigger(trigger, data.Reboot, current.ValueString(), data.State.ValueString(), actions)
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
@@ -258,6 +394,14 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
+	if data.Shutdown.ValueInt64() > 0 {
+		err := powerGracefulStop(ctx, r.powerActions(ctx, data.ID.ValueString(), data.Shutdown))
+		if err != nil {
+			errorDeleteGeneric(resp, err)
+			return
+		}
+	}
+
 	_, err := r.Data.K.InstanceAPI.DeleteInstance(ctx, data.ID.ValueString()).Execute()
 	if err != nil {
 		errorDeleteGeneric(resp, err)
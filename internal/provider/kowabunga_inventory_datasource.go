@@ -0,0 +1,292 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	InventoryDataSourceName                 = "inventory"
+	InventoryDataSourceSchemaDescription    = "Data from a project's instances, exported as a dynamic inventory"
+	InventoryDataSourceProjectDescription   = "Associated project name or ID"
+	InventoryDataSourceInstancesDescription = "List of project's instances (read-only)"
+	InventoryDataSourceAnsibleDescription   = "JSON-encoded Ansible dynamic inventory (read-only). Instances are grouped by zone and by project tags. Hosts are named `<instance>.<zone>`, as instances names are only unique within a zone. Groups named after Ansible reserved ones (`all`, `ungrouped`, `_meta`) are prefixed with `kowabunga_`."
+
+	InventoryAnsibleGroupAll       = "all"
+	InventoryAnsibleGroupUngrouped = "ungrouped"
+	InventoryAnsibleGroupMeta      = "_meta"
+	InventoryAnsibleGroupPrefix    = "kowabunga_"
+)
+
+// Ansible inventory top-level keys, that neither zones nor tags can be named
+// after
+var inventoryAnsibleReserved = []string{
+	InventoryAnsibleGroupAll,
+	InventoryAnsibleGroupUngrouped,
+	InventoryAnsibleGroupMeta,
+}
+
+var inventoryAnsibleGroupRegexp = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+var _ datasource.DataSource = &InventoryDataSource{}
+var _ datasource.DataSourceWithConfigure = &InventoryDataSource{}
+
+func NewInventoryDataSource() datasource.DataSource {
+	return &InventoryDataSource{}
+}
+
+type InventoryDataSource struct {
+	Data *KowabungaProviderData
+}
+
+type InventoryDataSourceModel struct {
+	Project   types.String             `tfsdk:"project"`
+	Instances []InventoryInstanceModel `tfsdk:"instances"`
+	Ansible   types.String             `tfsdk:"ansible_inventory"`
+}
+
+type InventoryInstanceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Zone      types.String   `tfsdk:"zone"`
+	Addresses []types.String `tfsdk:"addresses"`
+	Tags      []types.String `tfsdk:"tags"`
+}
+
+type inventoryAnsibleGroup struct {
+	Hosts []string `json:"hosts"`
+}
+
+type inventoryAnsibleMeta struct {
+	HostVars map[string]map[string]any `json:"hostvars"`
+}
+
+func inventoryDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyProject: schema.StringAttribute{
+			MarkdownDescription: InventoryDataSourceProjectDescription,
+			Required:            true,
+		},
+		KeyInstances: schema.ListNestedAttribute{
+			MarkdownDescription: InventoryDataSourceInstancesDescription,
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					KeyID: schema.StringAttribute{
+						MarkdownDescription: "Instance ID",
+						Computed:            true,
+					},
+					KeyName: schema.StringAttribute{
+						MarkdownDescription: "Instance name",
+						Computed:            true,
+					},
+					KeyZone: schema.StringAttribute{
+						MarkdownDescription: "Instance zone name",
+						Computed:            true,
+					},
+					KeyAddresses: schema.ListAttribute{
+						MarkdownDescription: "Instance IPv4 addresses, from all network adapters",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					KeyTags: schema.ListAttribute{
+						MarkdownDescription: "Instance tags, inherited from project",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+				},
+			},
+		},
+		KeyAnsibleInventory: schema.StringAttribute{
+			MarkdownDescription: InventoryDataSourceAnsibleDescription,
+			Computed:            true,
+		},
+	}
+}
+
+func (d *InventoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, InventoryDataSourceName)
+}
+
+func (d *InventoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *InventoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: InventoryDataSourceSchemaDescription,
+		Attributes:          inventoryDatasourceAttributes(),
+	}
+}
+
+// converts a name into a valid Ansible group name, escaping reserved ones
+func inventoryAnsibleGroupName(name string) string {
+	name = inventoryAnsibleGroupRegexp.ReplaceAllString(name, "_")
+	if slices.Contains(inventoryAnsibleReserved, name) {
+		name = InventoryAnsibleGroupPrefix + name
+	}
+	return name
+}
+
+// returns an instance's Ansible host name, unique across zones (and never
+// colliding with a sanitized group name)
+func inventoryAnsibleHostName(i InventoryInstanceModel) string {
+	return i.Name.ValueString() + "." + i.Zone.ValueString()
+}
+
+// renders instances as an Ansible dynamic inventory JSON document
+func inventoryAnsible(instances []InventoryInstanceModel) (string, error) {
+	groups := map[string]*inventoryAnsibleGroup{
+		InventoryAnsibleGroupAll: {Hosts: []string{}},
+	}
+	meta := inventoryAnsibleMeta{
+		HostVars: map[string]map[string]any{},
+	}
+
+	addToGroup := func(group, host string) {
+		if _, ok := groups[group]; !ok {
+			groups[group] = &inventoryAnsibleGroup{Hosts: []string{}}
+		}
+		groups[group].Hosts = append(groups[group].Hosts, host)
+	}
+
+	for _, i := range instances {
+		host := inventoryAnsibleHostName(i)
+		addToGroup(InventoryAnsibleGroupAll, host)
+		addToGroup(inventoryAnsibleGroupName(i.Zone.ValueString()), host)
+
+		tags := []string{}
+		for _, t := range i.Tags {
+			tags = append(tags, t.ValueString())
+			addToGroup(inventoryAnsibleGroupName(t.ValueString()), host)
+		}
+
+		vars := map[string]any{
+			"kowabunga_id":   i.ID.ValueString(),
+			"kowabunga_name": i.Name.ValueString(),
+			"kowabunga_zone": i.Zone.ValueString(),
+			"kowabunga_tags": tags,
+		}
+		if len(i.Addresses) > 0 {
+			vars["ansible_host"] = i.Addresses[0].ValueString()
+		}
+		meta.HostVars[host] = vars
+	}
+
+	inventory := map[string]any{
+		InventoryAnsibleGroupMeta: meta,
+	}
+	for name, g := range groups {
+		inventory[name] = g
+	}
+
+	b, err := json.Marshal(inventory)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *InventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data InventoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	// find parent project
+	projectId, err := getProjectID(ctx, d.Data, data.Project.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	project, _, err := d.Data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	tags := []types.String{}
+	for _, t := range project.Tags {
+		tags = append(tags, types.StringValue(t))
+	}
+
+	data.Instances = []InventoryInstanceModel{}
+	for _, regionId := range project.Regions {
+		zones, _, err := d.Data.K.RegionAPI.ListRegionZones(ctx, regionId).Execute()
+		if err != nil {
+			errorDataSourceReadGeneric(resp, err)
+			return
+		}
+
+		for _, zoneId := range zones {
+			zone, _, err := d.Data.K.ZoneAPI.ReadZone(ctx, zoneId).Execute()
+			if err != nil {
+				errorDataSourceReadGeneric(resp, err)
+				return
+			}
+
+			instances, _, err := d.Data.K.ProjectAPI.ListProjectZoneInstances(ctx, projectId, zoneId).Execute()
+			if err != nil {
+				errorDataSourceReadGeneric(resp, err)
+				return
+			}
+
+			for _, instanceId := range instances {
+				instance, _, err := d.Data.K.InstanceAPI.ReadInstance(ctx, instanceId).Execute()
+				if err != nil {
+					errorDataSourceReadGeneric(resp, err)
+					return
+				}
+
+				addresses := []types.String{}
+				for _, adapterId := range instance.Adapters {
+					adapter, _, err := d.Data.K.AdapterAPI.ReadAdapter(ctx, adapterId).Execute()
+					if err != nil {
+						errorDataSourceReadGeneric(resp, err)
+						return
+					}
+					for _, a := range adapter.Addresses {
+						addresses = append(addresses, types.StringValue(a))
+					}
+				}
+
+				data.Instances = append(data.Instances, InventoryInstanceModel{
+					ID:        types.StringPointerValue(instance.Id),
+					Name:      types.StringValue(instance.Name),
+					Zone:      types.StringValue(zone.Name),
+					Addresses: addresses,
+					Tags:      tags,
+				})
+			}
+		}
+	}
+
+	sort.Slice(data.Instances, func(i, j int) bool {
+		return inventoryAnsibleHostName(data.Instances[i]) < inventoryAnsibleHostName(data.Instances[j])
+	})
+
+	inventory, err := inventoryAnsible(data.Instances)
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	data.Ansible = types.StringValue(inventory)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInventoryAnsible(t *testing.T) {
+	instance := func(name, zone string, tags ...string) InventoryInstanceModel {
+		i := InventoryInstanceModel{
+			ID:   types.StringValue(name + "-id"),
+			Name: types.StringValue(name),
+			Zone: types.StringValue(zone),
+		}
+		for _, tag := range tags {
+			i.Tags = append(i.Tags, types.StringValue(tag))
+		}
+		return i
+	}
+
+	inventory, err := inventoryAnsible([]InventoryInstanceModel{
+		instance("vm1", "all", "_meta", "ungrouped", "web"),
+		instance("vm1", "web"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var groups map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(inventory), &groups); err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for name := range groups {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{"_meta", "all", "kowabunga__meta", "kowabunga_all", "kowabunga_ungrouped", "web"}
+	if !slices.Equal(names, want) {
+		t.Errorf("groups: got %v, want %v", names, want)
+	}
+	var meta inventoryAnsibleMeta
+	if err := json.Unmarshal(groups[InventoryAnsibleGroupMeta], &meta); err != nil {
+		t.Fatalf("_meta overridden: %s", groups[InventoryAnsibleGroupMeta])
+	}
+
+	// same instance name in different zones
+	hosts := slices.Sorted(maps.Keys(meta.HostVars))
+	if want := []string{"vm1.all", "vm1.web"}; !slices.Equal(hosts, want) {
+		t.Errorf("hostvars: got %v, want %v", hosts, want)
+	}
+	var web inventoryAnsibleGroup
+	if err := json.Unmarshal(groups["web"], &web); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"vm1.all", "vm1.web"}; !slices.Equal(web.Hosts, want) {
+		t.Errorf("web group: got %v, want %v", web.Hosts, want)
+	}
+}
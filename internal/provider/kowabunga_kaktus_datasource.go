@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	KaktusDataSourceName = "kaktus"
+)
+
+type KaktusDataSourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	Desc             types.String   `tfsdk:"desc"`
+	CpuPrice         types.Float64  `tfsdk:"cpu_price"`
+	MemoryPrice      types.Float64  `tfsdk:"memory_price"`
+	Currency         types.String   `tfsdk:"currency"`
+	CpuOvercommit    types.Int64    `tfsdk:"cpu_overcommit"`
+	MemoryOvercommit types.Int64    `tfsdk:"memory_overcommit"`
+	Agents           []types.String `tfsdk:"agents"`
+	VCPUs            types.Int64    `tfsdk:"vcpus"`
+	VCPUsUsed        types.Int64    `tfsdk:"vcpus_used"`
+	Memory           types.Int64    `tfsdk:"mem"`
+	MemoryUsed       types.Int64    `tfsdk:"mem_used"`
+	InstancesCount   types.Int64    `tfsdk:"instances_count"`
+}
+
+func kaktusDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "Kaktus computing node name or ID",
+			Required:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: "Kaktus computing node description",
+			Computed:            true,
+		},
+		KeyCpuPrice: schema.Float64Attribute{
+			MarkdownDescription: "Kaktus node global CPU monthly price",
+			Computed:            true,
+		},
+		KeyMemoryPrice: schema.Float64Attribute{
+			MarkdownDescription: "Kaktus node global memory monthly price",
+			Computed:            true,
+		},
+		KeyCurrency: schema.StringAttribute{
+			MarkdownDescription: "Kaktus node prices currency",
+			Computed:            true,
+		},
+		KeyCpuOvercommit: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node CPU over-commit factor",
+			Computed:            true,
+		},
+		KeyMemoryOvercommit: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node memory over-commit factor",
+			Computed:            true,
+		},
+		KeyAgents: schema.ListAttribute{
+			MarkdownDescription: "Kowabunga remote agents managing the Kaktus node",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		KeyVCPUs: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node total number of CPU threads, over-commit factor excluded",
+			Computed:            true,
+		},
+		KeyVCPUsUsed: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node number of vCPUs allocated to instances",
+			Computed:            true,
+		},
+		KeyMemory: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node total memory size (expressed in GB), over-commit factor excluded",
+			Computed:            true,
+		},
+		KeyMemoryUsed: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node memory size allocated to instances (expressed in GB)",
+			Computed:            true,
+		},
+		KeyInstancesCount: schema.Int64Attribute{
+			MarkdownDescription: "Kaktus node number of running instances",
+			Computed:            true,
+		},
+	}
+}
+
+var _ datasource.DataSource = &KaktusDataSource{}
+var _ datasource.DataSourceWithConfigure = &KaktusDataSource{}
+
+func NewKaktusDataSource() datasource.DataSource {
+	return &KaktusDataSource{}
+}
+
+type KaktusDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *KaktusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, KaktusDataSourceName)
+}
+
+func (d *KaktusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *KaktusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", KaktusDataSourceName),
+		Attributes:          kaktusDatasourceAttributes(),
+	}
+}
+
+func (d *KaktusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KaktusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	kaktusId, err := getKaktusID(ctx, d.Data, data.Name.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	kaktus, _, err := d.Data.K.KaktusAPI.ReadKaktus(ctx, kaktusId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	data.ID = types.StringPointerValue(kaktus.Id)
+	if kaktus.Description != nil {
+		data.Desc = types.StringPointerValue(kaktus.Description)
+	} else {
+		data.Desc = types.StringValue("")
+	}
+	data.CpuPrice = types.Float64Value(float64(kaktus.CpuCost.GetPrice()))
+	data.MemoryPrice = types.Float64Value(float64(kaktus.MemoryCost.GetPrice()))
+	data.Currency = types.StringValue(kaktus.CpuCost.GetCurrency())
+	data.CpuOvercommit = types.Int64Value(KaktusDefaultValueCpuOverCommit)
+	if kaktus.OvercommitCpuRatio != nil {
+		data.CpuOvercommit = types.Int64PointerValue(kaktus.OvercommitCpuRatio)
+	}
+	data.MemoryOvercommit = types.Int64Value(KaktusDefaultValueMemoryOverCommit)
+	if kaktus.OvercommitMemoryRatio != nil {
+		data.MemoryOvercommit = types.Int64PointerValue(kaktus.OvercommitMemoryRatio)
+	}
+	data.Agents = []types.String{}
+	for _, a := range kaktus.Agents {
+		data.Agents = append(data.Agents, types.StringValue(a))
+	}
+
+	// capacity and utilization
+	caps, _, err := d.Data.K.KaktusAPI.ReadKaktusCaps(ctx, kaktusId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	count, vcpus, memory, err := kaktusAllocation(ctx, d.Data, kaktusId)
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	data.VCPUs = types.Int64Value(caps.Cpu.Sockets * caps.Cpu.Cores * caps.Cpu.Threads)
+	data.VCPUsUsed = types.Int64Value(vcpus)
+	data.Memory = types.Int64Value(caps.Memory / HelperGbToBytes)
+	data.MemoryUsed = types.Int64Value(memory / HelperGbToBytes)
+	data.InstancesCount = types.Int64Value(count)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
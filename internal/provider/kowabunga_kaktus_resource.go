@@ -8,17 +8,22 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"net/http"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -29,10 +34,14 @@ const (
 	KaktusDefaultValueCurrent          = "EUR"
 	KaktusDefaultValueCpuOverCommit    = 3
 	KaktusDefaultValueMemoryOverCommit = 2
+	KaktusDefaultValueLiveCheck        = false
+
+	KaktusWarnOverPacked = "Kaktus node would become over-packed"
 )
 
 var _ resource.Resource = &KaktusResource{}
 var _ resource.ResourceWithImportState = &KaktusResource{}
+var _ resource.ResourceWithModifyPlan = &KaktusResource{}
 
 func NewKaktusResource() resource.Resource {
 	return &KaktusResource{}
@@ -54,6 +63,7 @@ type KaktusResourceModel struct {
 	CpuOvercommit    types.Int64    `tfsdk:"cpu_overcommit"`
 	MemoryOvercommit types.Int64    `tfsdk:"memory_overcommit"`
 	Agents           types.List     `tfsdk:"agents"`
+	LiveCheck        types.Bool     `tfsdk:"live_check"`
 }
 
 func (r *KaktusResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -89,10 +99,13 @@ func (r *KaktusResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             float64default.StaticFloat64(0),
 			},
 			KeyCurrency: schema.StringAttribute{
-				MarkdownDescription: "Kaktus node monthly price currency (default: **EUR**)",
+				MarkdownDescription: "Kaktus node monthly price ISO-4217 currency code (default: **EUR**). All kaktus nodes and storage pools of a region must share the same currency, unless provider allows mixed currencies.",
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString(KaktusDefaultValueCurrent),
+				Validators: []validator.String{
+					&stringCurrencyValidator{},
+				},
 			},
 			KeyCpuOvercommit: schema.Int64Attribute{
 				MarkdownDescription: "Kaktus node CPU over-commit factor (default: 3)",
@@ -111,11 +124,129 @@ func (r *KaktusResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				ElementType:         types.StringType,
 				Required:            true,
 			},
+			KeyLiveCheck: schema.BoolAttribute{
+				MarkdownDescription: "Whether to check kaktus node's live capacity and utilization at plan time when lowering over-commit factors, warning if the node would become over-packed (default: **false**)",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(KaktusDefaultValueLiveCheck),
+			},
 		},
 	}
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
 }
 
+// ensures kaktus node's currency matches region's other costs ones
+func (r *KaktusResource) checkCurrency(ctx context.Context, plan, state *KaktusResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.Zone.IsUnknown() || plan.Currency.IsUnknown() {
+		return
+	}
+	if state != nil && plan.Zone.Equal(state.Zone) && plan.Currency.Equal(state.Currency) {
+		return
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	zoneId, err := getZoneID(ctx, r.Data, plan.Zone.ValueString())
+	if err != nil {
+		return // reported at apply time
+	}
+	regionId, err := getZoneRegionID(ctx, r.Data, zoneId)
+	if err != nil {
+		return
+	}
+	checkRegionCurrency(ctx, r.Data, regionId, plan.Currency.ValueString(), plan.ID.ValueString(), &resp.Diagnostics)
+}
+
+// ensures kaktus node's currency is consistent within region and checks
+// whether its hosted instances still fit with lowered over-commit factors
+func (r *KaktusResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.Data == nil {
+		return
+	}
+
+	var plan, state KaktusResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		r.checkCurrency(ctx, &plan, nil, resp)
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.checkCurrency(ctx, &plan, &state, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.LiveCheck.ValueBool() || plan.CpuOvercommit.IsUnknown() || plan.MemoryOvercommit.IsUnknown() {
+		return
+	}
+
+	cpuLowered := plan.CpuOvercommit.ValueInt64() < state.CpuOvercommit.ValueInt64()
+	memLowered := plan.MemoryOvercommit.ValueInt64() < state.MemoryOvercommit.ValueInt64()
+	if !cpuLowered && !memLowered {
+		return
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	caps, _, err := r.Data.K.KaktusAPI.ReadKaktusCaps(ctx, state.ID.ValueString()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddWarning(KaktusWarnOverPacked, "unable to retrieve kaktus node capabilities: "+err.Error())
+		return
+	}
+	_, vcpus, memory, err := kaktusAllocation(ctx, r.Data, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning(KaktusWarnOverPacked, "unable to retrieve kaktus node instances: "+err.Error())
+		return
+	}
+
+	cpus := caps.Cpu.Sockets * caps.Cpu.Cores * caps.Cpu.Threads
+	if cpuLowered && vcpus > cpus*plan.CpuOvercommit.ValueInt64() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root(KeyCpuOvercommit),
+			KaktusWarnOverPacked,
+			fmt.Sprintf("%d vCPUs are currently allocated, exceeding the %d CPUs x %d over-commit factor capacity", vcpus, cpus, plan.CpuOvercommit.ValueInt64()),
+		)
+	}
+	if memLowered && memory > caps.Memory*plan.MemoryOvercommit.ValueInt64() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root(KeyMemoryOvercommit),
+			KaktusWarnOverPacked,
+			fmt.Sprintf("%d GB of memory are currently allocated, exceeding the %d GB x %d over-commit factor capacity", memory/HelperGbToBytes, caps.Memory/HelperGbToBytes, plan.MemoryOvercommit.ValueInt64()),
+		)
+	}
+}
+
+// returns the number of instances running on a kaktus node, along with the
+// vCPUs and memory (in bytes) allocated to them
+func kaktusAllocation(ctx context.Context, data *KowabungaProviderData, kaktusId string) (int64, int64, int64, error) {
+	instances, _, err := data.K.KaktusAPI.ListKaktusInstances(ctx, kaktusId).Execute()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var vcpus, memory int64
+	for _, id := range instances {
+		instance, _, err := data.K.InstanceAPI.ReadInstance(ctx, id).Execute()
+		if err != nil {
+			continue
+		}
+		vcpus += instance.Vcpus
+		memory += instance.Memory
+	}
+
+	return int64(len(instances)), vcpus, memory, nil
+}
+
 // converts kaktus from Terraform model to Kowabunga API model
 func kaktusResourceToModel(d *KaktusResourceModel) sdk.Kaktus {
 	agents := []string{}
@@ -203,6 +334,7 @@ func (r *KaktusResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	data.ID = types.StringPointerValue(kaktus.Id)
 	kaktusModelToResource(kaktus, data) // read back resulting object
+	r.Data.IDs.invalidate(KaktusResourceName)
 	tflog.Trace(ctx, "created kaktus resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -231,6 +363,18 @@ func (r *KaktusResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// resolve parent reference, unknown after import
+	if data.Zone.IsNull() {
+		zone, err := getZoneParent(ctx, r.Data, data.ID.ValueString(), func(zoneId string) ([]string, *http.Response, error) {
+			return r.Data.K.ZoneAPI.ListZoneKaktuses(ctx, zoneId).Execute()
+		})
+		if err != nil {
+			errorReadGeneric(resp, err)
+			return
+		}
+		data.Zone = types.StringValue(zone)
+	}
+
 	kaktusModelToResource(kaktus, data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -259,6 +403,7 @@ func (r *KaktusResource) Update(ctx context.Context, req resource.UpdateRequest,
 		errorUpdateGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(KaktusResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -284,5 +429,6 @@ func (r *KaktusResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(KaktusResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	KawaiiIPsecDataSourceName = "kawaii_ipsec"
+)
+
+type KawaiiIPsecDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	Kawaii                    types.String `tfsdk:"kawaii"`
+	Name                      types.String `tfsdk:"name"`
+	Desc                      types.String `tfsdk:"desc"`
+	IP                        types.String `tfsdk:"ip"`
+	RemotePeer                types.String `tfsdk:"remote_peer"`
+	RemoteSubnet              types.String `tfsdk:"remote_subnet"`
+	DpdTimeout                types.String `tfsdk:"dpd_timeout"`
+	DpdTimeoutAction          types.String `tfsdk:"dpd_action"`
+	StartAction               types.String `tfsdk:"start_action"`
+	Rekey                     types.String `tfsdk:"rekey"`
+	Phase1Lifetime            types.String `tfsdk:"phase1_lifetime"`
+	Phase1DHGroupNumber       types.Int64  `tfsdk:"phase1_dh_group_number"`
+	Phase1IntegrityAlgorithm  types.String `tfsdk:"phase1_integrity_algorithm"`
+	Phase1EncryptionAlgorithm types.String `tfsdk:"phase1_encryption_algorithm"`
+	Phase2Lifetime            types.String `tfsdk:"phase2_lifetime"`
+	Phase2DHGroupNumber       types.Int64  `tfsdk:"phase2_dh_group_number"`
+	Phase2IntegrityAlgorithm  types.String `tfsdk:"phase2_integrity_algorithm"`
+	Phase2EncryptionAlgorithm types.String `tfsdk:"phase2_encryption_algorithm"`
+}
+
+func kawaiiIPsecDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyKawaii: schema.StringAttribute{
+			MarkdownDescription: "Associated Kawaii name or ID",
+			Required:            true,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "IPsec connection name or ID",
+			Required:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: "IPsec connection description",
+			Computed:            true,
+		},
+		KeyIP: schema.StringAttribute{
+			MarkdownDescription: "The local IPsec IP",
+			Computed:            true,
+		},
+		KeyRemotePeer: schema.StringAttribute{
+			MarkdownDescription: "Remote VPN Gateway",
+			Computed:            true,
+		},
+		KeyRemoteSubnet: schema.StringAttribute{
+			MarkdownDescription: "Remote Subnet CIDR",
+			Computed:            true,
+		},
+		KeyIPsecDpdTimeout: schema.StringAttribute{
+			MarkdownDescription: "Dead Peer Detection Timeout",
+			Computed:            true,
+		},
+		KeyIPsecDpdAction: schema.StringAttribute{
+			MarkdownDescription: "Dead Peer Detection Timeout Action",
+			Computed:            true,
+		},
+		KeyIPsecStartAction: schema.StringAttribute{
+			MarkdownDescription: "IPsec Default Start Action",
+			Computed:            true,
+		},
+		KeyIPsecRekeyTime: schema.StringAttribute{
+			MarkdownDescription: "IPsec Rekey time",
+			Computed:            true,
+		},
+		KeyIPsecP1Lifetime: schema.StringAttribute{
+			MarkdownDescription: "IPsec phase 1 Lifetime",
+			Computed:            true,
+		},
+		KeyIPsecP1DHGroupNumber: schema.Int64Attribute{
+			MarkdownDescription: "IPsec phase 1 Diffie Hellman IANA Group Number",
+			Computed:            true,
+		},
+		KeyIPsecP1IntegrityAlgorithm: schema.StringAttribute{
+			MarkdownDescription: "IPsec phase 1 Integrity Algorithm",
+			Computed:            true,
+		},
+		KeyIPsecP1EncryptionAlgorithm: schema.StringAttribute{
+			MarkdownDescription: "IPsec phase 1 Encryption Algorithm",
+			Computed:            true,
+		},
+		KeyIPsecP2Lifetime: schema.StringAttribute{
+			MarkdownDescription: "IPsec phase 2 Lifetime",
+			Computed:            true,
+		},
+		KeyIPsecP2DHGroupNumber: schema.Int64Attribute{
+			MarkdownDescription: "IPsec phase 2 Diffie Hellman IANA Group Number",
+			Computed:            true,
+		},
+		KeyIPsecP2IntegrityAlgorithm: schema.StringAttribute{
+			MarkdownDescription: "IPsec phase 2 Integrity Algorithm",
+			Computed:            true,
+		},
+		KeyIPsecP2EncryptionAlgorithm: schema.StringAttribute{
+			MarkdownDescription: "IPsec phase 2 Encryption Algorithm",
+			Computed:            true,
+		},
+	}
+}
+
+var _ datasource.DataSource = &KawaiiIPsecDataSource{}
+var _ datasource.DataSourceWithConfigure = &KawaiiIPsecDataSource{}
+
+func NewKawaiiIPsecDataSource() datasource.DataSource {
+	return &KawaiiIPsecDataSource{}
+}
+
+type KawaiiIPsecDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *KawaiiIPsecDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, KawaiiIPsecDataSourceName)
+}
+
+func (d *KawaiiIPsecDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *KawaiiIPsecDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource. Pre-Shared Key is never exposed.", KawaiiIPsecDataSourceName),
+		Attributes:          kawaiiIPsecDatasourceAttributes(),
+	}
+}
+
+func (d *KawaiiIPsecDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KawaiiIPsecDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, d.Data, data.Kawaii.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	connections, _, err := d.Data.K.KawaiiAPI.ListKawaiiIpSecs(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	for _, id := range connections {
+		c, _, err := d.Data.K.KawaiiAPI.ReadKawaiiIpSec(ctx, kawaiiId, id).Execute()
+		if err != nil || (c.Name != data.Name.ValueString() && c.GetId() != data.Name.ValueString()) {
+			continue
+		}
+
+		data.ID = types.StringPointerValue(c.Id)
+		data.Desc = types.StringValue(c.GetDescription())
+		data.IP = types.StringValue(c.GetIp())
+		data.RemotePeer = types.StringValue(c.RemoteIp)
+		data.RemoteSubnet = types.StringValue(c.RemoteSubnet)
+		data.DpdTimeout = types.StringValue(KawaiiIPsecDefaultDpdTimeout)
+		if c.DpdTimeout != nil {
+			data.DpdTimeout = types.StringPointerValue(c.DpdTimeout)
+		}
+		data.DpdTimeoutAction = types.StringValue(KawaiiIPsecDefaultDpdAction)
+		if c.DpdTimeoutAction != nil {
+			data.DpdTimeoutAction = types.StringPointerValue(c.DpdTimeoutAction)
+		}
+		data.StartAction = types.StringValue(KawaiiIPsecDefaultStartAction)
+		if c.StartAction != nil {
+			data.StartAction = types.StringPointerValue(c.StartAction)
+		}
+		data.Rekey = types.StringValue(KawaiiIPsecDefaultRekeyTime)
+		if c.RekeyTime != nil {
+			data.Rekey = types.StringPointerValue(c.RekeyTime)
+		}
+		data.Phase1Lifetime = types.StringValue(KawaiiIPsecDefaultPhaseLifetime)
+		if c.Phase1Lifetime != nil {
+			data.Phase1Lifetime = types.StringPointerValue(c.Phase1Lifetime)
+		}
+		data.Phase1DHGroupNumber = types.Int64Value(c.Phase1DhGroupNumber)
+		data.Phase1IntegrityAlgorithm = types.StringValue(c.Phase1IntegrityAlgorithm)
+		data.Phase1EncryptionAlgorithm = types.StringValue(c.Phase1EncryptionAlgorithm)
+		data.Phase2Lifetime = types.StringValue(KawaiiIPsecDefaultPhaseLifetime)
+		if c.Phase2Lifetime != nil {
+			data.Phase2Lifetime = types.StringPointerValue(c.Phase2Lifetime)
+		}
+		data.Phase2DHGroupNumber = types.Int64Value(c.Phase2DhGroupNumber)
+		data.Phase2IntegrityAlgorithm = types.StringValue(c.Phase2IntegrityAlgorithm)
+		data.Phase2EncryptionAlgorithm = types.StringValue(c.Phase2EncryptionAlgorithm)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	errorDataSourceReadGeneric(resp, fmt.Errorf("%s", ErrorUnknownKawaiiIPsec))
+}
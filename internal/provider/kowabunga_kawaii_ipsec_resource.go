@@ -10,11 +10,18 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -22,6 +29,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -38,10 +46,16 @@ const (
 	KawaiiIPsecDefaultStartAction   = "start"
 	KawaiiIPsecDefaultRekeyTime     = "2h"
 	KawaiiIPsecDefaultPhaseLifetime = "1h"
+
+	KawaiiIPsecErrIncompatibleDpdAction = "Incompatible DPD action"
+
+	KawaiiIPsecPrivateKeyImported = "imported"
 )
 
 var _ resource.Resource = &KawaiiResource{}
 var _ resource.ResourceWithImportState = &KawaiiIPsecConnectionResource{}
+var _ resource.ResourceWithValidateConfig = &KawaiiIPsecConnectionResource{}
+var _ resource.ResourceWithModifyPlan = &KawaiiIPsecConnectionResource{}
 
 func NewKawaiiIPsecResource() resource.Resource {
 	return &KawaiiIPsecConnectionResource{}
@@ -56,25 +70,27 @@ type KawaiiIPsecConnectionResourceModel struct {
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 	Desc     types.String   `tfsdk:"desc"`
 
-	KawaiiID                  types.String `tfsdk:"kawaii"`
-	Name                      types.String `tfsdk:"name"`
-	IP                        types.String `tfsdk:"ip"`
-	PreSharedKey              types.String `tfsdk:"pre_shared_key"`
-	RemotePeer                types.String `tfsdk:"remote_peer"`
-	RemoteSubnet              types.String `tfsdk:"remote_subnet"`
-	DpdTimeout                types.String `tfsdk:"dpd_timeout"`
-	DpdTimeoutAction          types.String `tfsdk:"dpd_action"`
-	StartAction               types.String `tfsdk:"start_action"`
-	Rekey                     types.String `tfsdk:"rekey"`
-	Phase1Lifetime            types.String `tfsdk:"phase1_lifetime"`
-	Phase1DHGroupNumber       types.Int64  `tfsdk:"phase1_dh_group_number"`
-	Phase1IntegrityAlgorithm  types.String `tfsdk:"phase1_integrity_algorithm"`
-	Phase1EncryptionAlgorithm types.String `tfsdk:"phase1_encryption_algorithm"`
-	Phase2Lifetime            types.String `tfsdk:"phase2_lifetime"`
-	Phase2DHGroupNumber       types.Int64  `tfsdk:"phase2_dh_group_number"`
-	Phase2IntegrityAlgorithm  types.String `tfsdk:"phase2_integrity_algorithm"`
-	Phase2EncryptionAlgorithm types.String `tfsdk:"phase2_encryption_algorithm"`
-	IngressRules              types.List   `tfsdk:"ingress_rules"` // KawaiiForwardRule
+	KawaiiID                  types.String        `tfsdk:"kawaii"`
+	Name                      types.String        `tfsdk:"name"`
+	IP                        types.String        `tfsdk:"ip"`
+	PreSharedKey              types.String        `tfsdk:"pre_shared_key"`
+	PreSharedKeyWO            types.String        `tfsdk:"pre_shared_key_wo"`
+	PreSharedKeyWOVersion     types.Int64         `tfsdk:"pre_shared_key_wo_version"`
+	RemotePeer                types.String        `tfsdk:"remote_peer"`
+	RemoteSubnet              NetworkAddressValue `tfsdk:"remote_subnet"`
+	DpdTimeout                types.String        `tfsdk:"dpd_timeout"`
+	DpdTimeoutAction          types.String        `tfsdk:"dpd_action"`
+	StartAction               types.String        `tfsdk:"start_action"`
+	Rekey                     types.String        `tfsdk:"rekey"`
+	Phase1Lifetime            types.String        `tfsdk:"phase1_lifetime"`
+	Phase1DHGroupNumber       types.Int64         `tfsdk:"phase1_dh_group_number"`
+	Phase1IntegrityAlgorithm  types.String        `tfsdk:"phase1_integrity_algorithm"`
+	Phase1EncryptionAlgorithm types.String        `tfsdk:"phase1_encryption_algorithm"`
+	Phase2Lifetime            types.String        `tfsdk:"phase2_lifetime"`
+	Phase2DHGroupNumber       types.Int64         `tfsdk:"phase2_dh_group_number"`
+	Phase2IntegrityAlgorithm  types.String        `tfsdk:"phase2_integrity_algorithm"`
+	Phase2EncryptionAlgorithm types.String        `tfsdk:"phase2_encryption_algorithm"`
+	IngressRules              types.List          `tfsdk:"ingress_rules"` // KawaiiForwardRule
 }
 
 func (r *KawaiiIPsecConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -82,7 +98,9 @@ func (r *KawaiiIPsecConnectionResource) Metadata(ctx context.Context, req resour
 }
 
 func (r *KawaiiIPsecConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resourceImportState(ctx, req, resp)
+	resourceImportStateWithParent(ctx, req, resp, KeyKawaii)
+	// PSK is to be read back once, see Read()
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, KawaiiIPsecPrivateKeyImported, []byte(`true`))...)
 }
 
 func (r *KawaiiIPsecConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -92,30 +110,49 @@ func (r *KawaiiIPsecConnectionResource) Configure(ctx context.Context, req resou
 func (r *KawaiiIPsecConnectionResource) SchemaIngressRule() schema.NestedAttributeObject {
 	return schema.NestedAttributeObject{
 		Attributes: map[string]schema.Attribute{
-			KeySource: schema.StringAttribute{
-				MarkdownDescription: "The source IP or CIDR to accept public traffic from (defaults to 0.0.0.0/0).",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(KawaiiDefaultValueSource),
-				Validators: []validator.String{
-					&stringNetworkAddressValidator{},
-				},
+			KeySource:   resourceNetworkAddressAttribute("The source IP or CIDR to accept tunnel traffic from", KawaiiDefaultValueSource),
+			KeyProtocol: resourceNetworkProtocolAttribute("to accept tunnel traffic from", KawaiiIPsecDefaultValueIngressProtocol),
+			KeyPorts:    resourceNetworkPortRangesAttribute("allowed to receive tunnel traffic"),
+		},
+	}
+}
+
+// returns IPsec phase 1 or 2 negotiation attributes
+func (r *KawaiiIPsecConnectionResource) SchemaPhase(phase int, lifetime, dhGroup, integrity, encryption string) map[string]schema.Attribute {
+	dhGroups := []string{}
+	for _, g := range diffieHellmanSupportedTypes {
+		dhGroups = append(dhGroups, strconv.FormatInt(g, 10))
+	}
+
+	return map[string]schema.Attribute{
+		lifetime: schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("IPsec phase %d Lifetime. Use s, m, h and d suffixes. Default is `%s`", phase, KawaiiIPsecDefaultPhaseLifetime),
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(KawaiiIPsecDefaultPhaseLifetime),
+			Validators: []validator.String{
+				&stringDurationValidator{},
 			},
-			KeyProtocol: schema.StringAttribute{
-				MarkdownDescription: "The transport layer protocol to forward public traffic to (defaults to 'tcp')",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(KawaiiIPsecDefaultValueIngressProtocol),
-				Validators: []validator.String{
-					&stringNetworkProtocolValidator{},
-				},
+		},
+		dhGroup: schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("IPsec phase %d Diffie Hellman IANA Group Number. Valid values are `%s`", phase, strings.Join(dhGroups, " | ")),
+			Required:            true,
+			Validators: []validator.Int64{
+				&diffieHellmanAlgorithmTypeValidator{},
 			},
-			KeyPorts: schema.StringAttribute{
-				MarkdownDescription: "The ports (or range of ports) allowed to receive traffic. Ranges are accepted. Format is a-b,c-d (e.g. 443; 22,80,443; 80,443,3000-3005).",
-				Required:            true,
-				Validators: []validator.String{
-					&stringNetworkPortRangesValidator{},
-				},
+		},
+		integrity: schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("IPsec phase %d Integrity Algorithm. Valid values are `%s`", phase, strings.Join(integritySupportedTypes, " | ")),
+			Required:            true,
+			Validators: []validator.String{
+				&integrityAlgorithmTypeValidator{},
+			},
+		},
+		encryption: schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("IPsec phase %d Encryption Algorithm. Valid values are `%s`", phase, strings.Join(encryptionSupportedTypes, " | ")),
+			Required:            true,
+			Validators: []validator.String{
+				&encryptionAlgorithmTypeValidator{},
 			},
 		},
 	}
@@ -123,7 +160,7 @@ func (r *KawaiiIPsecConnectionResource) SchemaIngressRule() schema.NestedAttribu
 
 func (r *KawaiiIPsecConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Kawaii list of Kowabunga IPsec Connections",
+		MarkdownDescription: "Kawaii list of Kowabunga IPsec Connections. Existing connection can be imported by `kawaii/ID` composite identifier, kawaii being either a name or an ID.",
 		Attributes: map[string]schema.Attribute{
 			KeyIP: schema.StringAttribute{
 				MarkdownDescription: "The local IPsec IP (read-only)",
@@ -133,11 +170,8 @@ func (r *KawaiiIPsecConnectionResource) Schema(ctx context.Context, req resource
 				},
 			},
 			KeyKawaii: schema.StringAttribute{
-				MarkdownDescription: "Associated Kawaii name or ID",
+				MarkdownDescription: "Associated Kawaii name or ID. Changing it to another Kawaii forces a new resource to be created.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			KeyName: schema.StringAttribute{
 				MarkdownDescription: "Kowabunga IPsec Connection Name",
@@ -151,11 +185,29 @@ func (r *KawaiiIPsecConnectionResource) Schema(ctx context.Context, req resource
 				},
 			},
 			KeyPreSharedKey: schema.StringAttribute{
-				MarkdownDescription: "The Pre-Shared Key (PSK) to authenticate the VPN tunnel to your peer VPN gateway",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The Pre-Shared Key (PSK) to authenticate the VPN tunnel to your peer VPN gateway. Stored in state, use `%s` to prevent it. Exactly one of `%s` or `%s` must be set. Actual key is read back into state on import, and only removed from it by next apply if `%s` is used.", KeyPreSharedKeyWO, KeyPreSharedKey, KeyPreSharedKeyWO, KeyPreSharedKeyWO),
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot(KeyPreSharedKeyWO)),
+				},
+			},
+			KeyPreSharedKeyWO: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Write-only Pre-Shared Key (PSK) to authenticate the VPN tunnel to your peer VPN gateway, never persisted in state (requires Terraform 1.11+). Bump `%s` to apply a new key", KeyPreSharedKeyWOVersion),
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			KeyPreSharedKeyWOVersion: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Version of the write-only `%s`, to be changed to trigger Pre-Shared Key update", KeyPreSharedKeyWO),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot(KeyPreSharedKeyWO)),
+				},
 			},
 			KeyRemoteSubnet: schema.StringAttribute{
 				MarkdownDescription: "Remote Subnet CIDR",
+				CustomType:          NetworkAddressType{},
 				Required:            true,
 				Validators: []validator.String{
 					&stringNetworkAddressValidator{},
@@ -168,16 +220,22 @@ func (r *KawaiiIPsecConnectionResource) Schema(ctx context.Context, req resource
 				Default:             stringdefault.StaticString(KawaiiIPsecDefaultDpdTimeout),
 			},
 			KeyIPsecDpdAction: schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("Dead Peer Detection Timeout Action. Default is `%s`", KawaiiIPsecDefaultDpdAction),
+				MarkdownDescription: fmt.Sprintf("Dead Peer Detection Timeout Action. Valid values are `clear | trap | restart`. Must be `clear` or `trap` for on-demand connections (i.e. `trap` and `trap|start` start actions). Default is `%s`", KawaiiIPsecDefaultDpdAction),
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(KawaiiIPsecDefaultDpdAction),
+				Validators: []validator.String{
+					&stringIPsecDpdActionValidator{},
+				},
 			},
 			KeyIPsecStartAction: schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("IPsec Default Start Action. Default is `%s`", KawaiiIPsecDefaultStartAction),
+				MarkdownDescription: fmt.Sprintf("IPsec Default Start Action. Valid values are `none | start | trap | trap|start`. Use `trap` to negotiate the tunnel on-demand, when matching traffic is first seen, instead of always starting it, or `trap|start` to start it right away while still re-negotiating it on-demand. Default is `%s`", KawaiiIPsecDefaultStartAction),
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(KawaiiIPsecDefaultStartAction),
+				Validators: []validator.String{
+					&stringIPsecStartActionValidator{},
+				},
 			},
 			KeyIPsecRekeyTime: schema.StringAttribute{
 				MarkdownDescription: fmt.Sprintf("IPsec Rekey time in seconds. Default is `%s`", KawaiiIPsecDefaultRekeyTime),
@@ -188,66 +246,6 @@ func (r *KawaiiIPsecConnectionResource) Schema(ctx context.Context, req resource
 					&stringDurationValidator{},
 				},
 			},
-			KeyIPsecP1Lifetime: schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("IPsec Phase 1 Lifetime. Use s, m, h and d suffixes. Default is `%s`", KawaiiIPsecDefaultPhaseLifetime),
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(KawaiiIPsecDefaultPhaseLifetime),
-				Validators: []validator.String{
-					&stringDurationValidator{},
-				},
-			},
-			KeyIPsecP1DHGroupNumber: schema.Int64Attribute{
-				MarkdownDescription: "IPsec phase 1 Diffie Hellman IANA Group Number. Valid values are `2 | 5 | 14 | 15 | 16 | 17 | 18 | 19 | 20 | 21 | 22 | 23 | 24`",
-				Required:            true,
-				Validators: []validator.Int64{
-					&diffieHellmanAlgorithmTypeValidator{},
-				},
-			},
-			KeyIPsecP1IntegrityAlgorithm: schema.StringAttribute{
-				MarkdownDescription: "IPsec phase 1 Integrity Algorithm. Valid values are `SHA1 | SHA2-256 | SHA2-384 | SHA2-512`",
-				Required:            true,
-				Validators: []validator.String{
-					&integrityAlgorithmTypeValidator{},
-				},
-			},
-			KeyIPsecP1EncryptionAlgorithm: schema.StringAttribute{
-				MarkdownDescription: "IPsec phase 1 Encryption Algorithm. Valid values are `AES128 | AES256 | CAMELLIA128 | CAMELLIA256`",
-				Required:            true,
-				Validators: []validator.String{
-					&encryptionAlgorithmTypeValidator{},
-				},
-			},
-			KeyIPsecP2Lifetime: schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("IPsec Phase 2 Lifetime. Use s, m, h and d suffixes. Default is `%s`", KawaiiIPsecDefaultPhaseLifetime),
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(KawaiiIPsecDefaultPhaseLifetime),
-				Validators: []validator.String{
-					&stringDurationValidator{},
-				},
-			},
-			KeyIPsecP2DHGroupNumber: schema.Int64Attribute{
-				MarkdownDescription: "IPsec phase 2 Diffie Hellman IANA Group Number. Valid values are `2 | 5 | 14 | 15 | 16 | 17 | 18 | 19 | 20 | 21 | 22 | 23 | 24`",
-				Required:            true,
-				Validators: []validator.Int64{
-					&diffieHellmanAlgorithmTypeValidator{},
-				},
-			},
-			KeyIPsecP2IntegrityAlgorithm: schema.StringAttribute{
-				MarkdownDescription: "IPsec phase 1 Integrity Algorithm. Valid values are `SHA1 | SHA2-256 | SHA2-384 | SHA2-512`",
-				Required:            true,
-				Validators: []validator.String{
-					&integrityAlgorithmTypeValidator{},
-				},
-			},
-			KeyIPsecP2EncryptionAlgorithm: schema.StringAttribute{
-				MarkdownDescription: "IPsec phase 1 Encryption Algorithm. Valid values are `AES128 | AES256 | CAMELLIA128 | CAMELLIA256`",
-				Required:            true,
-				Validators: []validator.String{
-					&encryptionAlgorithmTypeValidator{},
-				},
-			},
 			KeyIngressRules: schema.ListNestedAttribute{
 				MarkdownDescription: "The firewall list of Ingress Rules. Default will accept all. Egress is allow all",
 				Optional:            true,
@@ -259,9 +257,41 @@ func (r *KawaiiIPsecConnectionResource) Schema(ctx context.Context, req resource
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, r.SchemaPhase(1, KeyIPsecP1Lifetime, KeyIPsecP1DHGroupNumber, KeyIPsecP1IntegrityAlgorithm, KeyIPsecP1EncryptionAlgorithm))
+	maps.Copy(resp.Schema.Attributes, r.SchemaPhase(2, KeyIPsecP2Lifetime, KeyIPsecP2DHGroupNumber, KeyIPsecP2IntegrityAlgorithm, KeyIPsecP2EncryptionAlgorithm))
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
 }
 
+func (r *KawaiiIPsecConnectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data KawaiiIPsecConnectionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.StartAction.IsUnknown() || data.DpdTimeoutAction.IsUnknown() {
+		return
+	}
+
+	startAction := data.StartAction.ValueString()
+	if data.StartAction.IsNull() {
+		startAction = KawaiiIPsecDefaultStartAction
+	}
+	dpdAction := data.DpdTimeoutAction.ValueString()
+	if data.DpdTimeoutAction.IsNull() {
+		dpdAction = KawaiiIPsecDefaultDpdAction
+	}
+
+	// on-demand connections are re-established by traffic, not by DPD
+	if slices.Contains(ipsecTrapStartActions, startAction) && !slices.Contains(ipsecTrapCompatibleDpdActions, dpdAction) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(KeyIPsecDpdAction),
+			KawaiiIPsecErrIncompatibleDpdAction,
+			fmt.Sprintf("%s: '%s' can't be used with on-demand start action '%s', use one of: %s", KawaiiIPsecErrIncompatibleDpdAction, dpdAction, startAction, strings.Join(ipsecTrapCompatibleDpdActions, ", ")),
+		)
+	}
+}
+
 // ////////////////////////////////////////////////////////////////////
 // converts kawaii Ipsec from Terraform model to Kowabunga API model //
 // ////////////////////////////////////////////////////////////////////
@@ -332,9 +362,9 @@ func kawaiiIPsecModelToIngressRules(ctx *context.Context, r *sdk.KawaiiIpSec, d
 	// ingress rules
 	ingressRules := []attr.Value{}
 	ingressRuleType := map[string]attr.Type{
-		KeySource:   types.StringType,
+		KeySource:   NetworkAddressType{},
 		KeyProtocol: types.StringType,
-		KeyPorts:    types.StringType,
+		KeyPorts:    PortRangesType{},
 	}
 	for _, ir := range r.Firewall.Ingress {
 		source := KawaiiDefaultValueSource
@@ -346,9 +376,9 @@ func kawaiiIPsecModelToIngressRules(ctx *context.Context, r *sdk.KawaiiIpSec, d
 			protocol = *ir.Protocol
 		}
 		r := map[string]attr.Value{
-			KeySource:   types.StringValue(source),
+			KeySource:   NewNetworkAddressValue(source),
 			KeyProtocol: types.StringValue(protocol),
-			KeyPorts:    types.StringValue(ir.Ports),
+			KeyPorts:    NewPortRangesValue(ir.Ports),
 		}
 		object, _ := types.ObjectValue(ingressRuleType, r)
 		ingressRules = append(ingressRules, object)
@@ -373,8 +403,11 @@ func kawaiiIPsecModelToResource(ctx *context.Context, r *sdk.KawaiiIpSec, d *Kaw
 		d.IP = types.StringValue("")
 	}
 	d.RemotePeer = types.StringValue(r.RemoteIp)
-	d.RemoteSubnet = types.StringValue(r.RemoteSubnet)
-	d.PreSharedKey = types.StringValue(r.PreSharedKey)
+	d.RemoteSubnet = NewNetworkAddressValue(r.RemoteSubnet)
+	// PSK is only tracked if not provided through write-only attribute
+	if !d.PreSharedKey.IsNull() {
+		d.PreSharedKey = types.StringValue(r.PreSharedKey)
+	}
 	if r.Description != nil {
 		d.Desc = types.StringPointerValue(r.Description)
 	} else {
@@ -383,12 +416,12 @@ func kawaiiIPsecModelToResource(ctx *context.Context, r *sdk.KawaiiIpSec, d *Kaw
 	if r.DpdTimeoutAction != nil {
 		d.DpdTimeoutAction = types.StringPointerValue(r.DpdTimeoutAction)
 	} else {
-		d.DpdTimeoutAction = types.StringValue(KawaiiIPsecDefaultDpdTimeout)
+		d.DpdTimeoutAction = types.StringValue(KawaiiIPsecDefaultDpdAction)
 	}
 	if r.DpdTimeout != nil {
 		d.DpdTimeout = types.StringPointerValue(r.DpdTimeout)
 	} else {
-		d.DpdTimeout = types.StringValue(KawaiiIPsecDefaultDpdAction)
+		d.DpdTimeout = types.StringValue(KawaiiIPsecDefaultDpdTimeout)
 	}
 	if r.StartAction != nil {
 		d.StartAction = types.StringPointerValue(r.StartAction)
@@ -419,6 +452,22 @@ func kawaiiIPsecModelToResource(ctx *context.Context, r *sdk.KawaiiIpSec, d *Kaw
 	kawaiiIPsecModelToIngressRules(ctx, r, d)
 }
 
+// write-only attributes are never part of plan or state, PSK has to be
+// retrieved from configuration when provided as such
+func kawaiiIPsecWriteOnlyPreSharedKey(ctx context.Context, config tfsdk.Config, m *sdk.KawaiiIpSec, diags *diag.Diagnostics) {
+	var psk types.String
+	diags.Append(config.GetAttribute(ctx, path.Root(KeyPreSharedKeyWO), &psk)...)
+	if diags.HasError() || psk.IsNull() || psk.IsUnknown() {
+		return
+	}
+	m.PreSharedKey = psk.ValueString()
+}
+
+// parent references are only replaced when changed to other objects
+func (r *KawaiiIPsecConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourcePlanParentReplace(ctx, r.Data, req, resp, KeyKawaii, getKawaiiID)
+}
+
 //////////////////////////////
 // Terraform CRUD Functions //
 //////////////////////////////
@@ -450,6 +499,10 @@ func (r *KawaiiIPsecConnectionResource) Create(ctx context.Context, req resource
 	}
 	// create a new Kawaii IPsec Connection
 	m := kawaiiIPsecResourceModel(&ctx, data)
+	kawaiiIPsecWriteOnlyPreSharedKey(ctx, req.Config, &m, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	kawaiiIpSec, _, err := r.Data.K.KawaiiAPI.CreateKawaiiIpSec(ctx, kawaiiId).KawaiiIpSec(m).Execute()
 	if err != nil {
 		errorCreateGeneric(resp, err)
@@ -479,13 +532,29 @@ func (r *KawaiiIPsecConnectionResource) Read(ctx context.Context, req resource.R
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
-	kawaiiIpSec, _, err := r.Data.K.KawaiiAPI.ReadKawaiiIpSec(ctx, data.KawaiiID.ValueString(), data.ID.ValueString()).Execute()
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+	kawaiiIpSec, _, err := r.Data.K.KawaiiAPI.ReadKawaiiIpSec(ctx, kawaiiId, data.ID.ValueString()).Execute()
 	if err != nil {
 		errorReadGeneric(resp, err)
 		return
 	}
 
 	kawaiiIPsecModelToResource(&ctx, kawaiiIpSec, data)
+
+	// PSK can't be told from configuration on import, it is read back so that
+	// the configured one doesn't show up as a diff (and removed from state
+	// on next apply if provided through write-only attribute)
+	imported, diags := req.Private.GetKey(ctx, KawaiiIPsecPrivateKeyImported)
+	resp.Diagnostics.Append(diags...)
+	if imported != nil {
+		data.PreSharedKey = types.StringValue(kawaiiIpSec.PreSharedKey)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, KawaiiIPsecPrivateKeyImported, nil)...)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -508,7 +577,16 @@ func (r *KawaiiIPsecConnectionResource) Update(ctx context.Context, req resource
 	defer r.Data.Mutex.Unlock()
 
 	m := kawaiiIPsecResourceModel(&ctx, data)
-	_, _, err := r.Data.K.KawaiiAPI.UpdateKawaiiIpSec(ctx, data.KawaiiID.ValueString(), data.ID.ValueString()).KawaiiIpSec(m).Execute()
+	kawaiiIPsecWriteOnlyPreSharedKey(ctx, req.Config, &m, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	_, _, err = r.Data.K.KawaiiAPI.UpdateKawaiiIpSec(ctx, kawaiiId, data.ID.ValueString()).KawaiiIpSec(m).Execute()
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
@@ -535,7 +613,12 @@ func (r *KawaiiIPsecConnectionResource) Delete(ctx context.Context, req resource
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
-	_, err := r.Data.K.KawaiiAPI.DeleteKawaiiIpSec(ctx, data.KawaiiID.ValueString(), data.ID.ValueString()).Execute()
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+	_, err = r.Data.K.KawaiiAPI.DeleteKawaiiIpSec(ctx, kawaiiId, data.ID.ValueString()).Execute()
 	if err != nil {
 		errorDeleteGeneric(resp, err)
 		return
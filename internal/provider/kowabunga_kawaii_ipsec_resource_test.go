@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// on-demand start actions can't be combined with an eager DPD restart
+func TestKawaiiIPsecValidateConfig(t *testing.T) {
+	r := &KawaiiIPsecConnectionResource{}
+	s := testResourceSchema(r)
+
+	tests := []struct {
+		start string
+		dpd   string
+		err   bool
+	}{
+		{start: IPsecStartActionStart, dpd: IPsecDpdActionRestart},
+		{start: IPsecStartActionNone, dpd: IPsecDpdActionRestart},
+		{start: IPsecStartActionTrap, dpd: IPsecDpdActionClear},
+		{start: IPsecStartActionTrapStart, dpd: IPsecDpdActionTrap},
+		{start: IPsecStartActionTrap, dpd: IPsecDpdActionRestart, err: true},
+		{start: IPsecStartActionTrapStart, dpd: IPsecDpdActionRestart, err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.start+"/"+tc.dpd, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: s,
+				Raw: testResourceValue(s, map[string]tftypes.Value{
+					KeyIPsecStartAction: tftypes.NewValue(tftypes.String, tc.start),
+					KeyIPsecDpdAction:   tftypes.NewValue(tftypes.String, tc.dpd),
+				}),
+			}
+			var resp resource.ValidateConfigResponse
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, &resp)
+			if got := resp.Diagnostics.HasError(); got != tc.err {
+				t.Errorf("got error %v, want %v (%v)", got, tc.err, resp.Diagnostics)
+			}
+		})
+	}
+}
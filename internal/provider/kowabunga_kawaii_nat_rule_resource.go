@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	KawaiiNatRuleResourceName = "kawaii_nat_rule"
+
+	KawaiiNatRuleErrAlreadyExists = "Kawaii already forwards ports"
+)
+
+var _ resource.Resource = &KawaiiNatRuleResource{}
+var _ resource.ResourceWithImportState = &KawaiiNatRuleResource{}
+var _ resource.ResourceWithModifyPlan = &KawaiiNatRuleResource{}
+
+func NewKawaiiNatRuleResource() resource.Resource {
+	return &KawaiiNatRuleResource{}
+}
+
+type KawaiiNatRuleResource struct {
+	Data *KowabungaProviderData
+}
+
+type KawaiiNatRuleResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	KawaiiID types.String   `tfsdk:"kawaii"`
+	KawaiiNatRule
+}
+
+func (r *KawaiiNatRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resourceMetadata(req, resp, KawaiiNatRuleResourceName)
+}
+
+func (r *KawaiiNatRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resourceImportStateWithParent(ctx, req, resp, KeyKawaii)
+}
+
+func (r *KawaiiNatRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.Data = resourceConfigure(req, resp)
+}
+
+func (r *KawaiiNatRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// same rule attributes as Kawaii inline ones, forwarded protocol and
+	// ports identifying the rule
+	attributes := maps.Clone((&KawaiiResource{}).SchemaNatRules().NestedObject.Attributes)
+	protocol := attributes[KeyProtocol].(schema.StringAttribute)
+	protocol.PlanModifiers = []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	attributes[KeyProtocol] = protocol
+	ports := attributes[KeyPorts].(schema.StringAttribute)
+	ports.PlanModifiers = []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	attributes[KeyPorts] = ports
+	attributes[KeyID] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Resource object internal identifier (i.e. forwarded `<protocol>:<ports>`)",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes[KeyKawaii] = schema.StringAttribute{
+		MarkdownDescription: "Associated Kawaii name or ID. Changing it to another Kawaii forces a new resource to be created.",
+		Required:            true,
+	}
+	attributes[KeyTimeouts] = timeouts.Attributes(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: DefaultCreateTimeout.String(),
+		ReadDescription:   DefaultReadTimeout.String(),
+		UpdateDescription: DefaultUpdateTimeout.String(),
+		DeleteDescription: DefaultDeleteTimeout.String(),
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a Kawaii NAT forwarding rule, i.e. a single `nat_rules` entry of a `kowabunga_%s` resource, which can then be registered by the application module it forwards traffic to. Kawaii inline `nat_rules` leave unlisted rules untouched, the very same protocol and ports must however not be declared both ways. Import with `<kawaii>/<protocol>:<ports>`.\n\n~> Rules being part of the Kawaii object, each change reads and rewrites the whole Kawaii. Changes from a single Terraform run are serialized, but **concurrent applies from several workspaces on the same Kawaii will overwrite each other's changes**: make sure such applies are never run concurrently.", KawaiiResourceName),
+		Attributes:          attributes,
+	}
+}
+
+// returns the index of the NAT rule with a given identifier, -1 if none
+func kawaiiNatRuleIndex(k *sdk.Kawaii, id string) int {
+	return slices.IndexFunc(k.Dnat, func(rule sdk.KawaiiDNatRule) bool {
+		return kawaiiDNatRuleID(&rule) == id
+	})
+}
+
+// parent references are only replaced when changed to other objects
+func (r *KawaiiNatRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourcePlanParentReplace(ctx, r.Data, req, resp, KeyKawaii, getKawaiiID)
+}
+
+//////////////////////////////
+// Terraform CRUD Functions //
+//////////////////////////////
+
+// rules are part of the Kawaii object, all changes are read-modify-write
+// ones, under a per-Kawaii lock which holds whatever provider's parallelism
+// setting (but can't prevent concurrent changes from other workspaces)
+
+func (r *KawaiiNatRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *KawaiiNatRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	// find parent kawaii
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	unlock := r.Data.Locks.lock(KawaiiResourceName, kawaiiId)
+	defer unlock()
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	id := kawaiiNatRuleID(data.Protocol.ValueString(), data.Ports.ValueString())
+	if kawaiiNatRuleIndex(kawaii, id) >= 0 {
+		resp.Diagnostics.AddError(KawaiiNatRuleErrAlreadyExists, fmt.Sprintf("Kawaii %s already forwards %s, import it instead", data.KawaiiID.ValueString(), id))
+		return
+	}
+
+	// add a new rule
+	kawaii.Dnat = append(kawaii.Dnat, kawaiiNatRuleModel(&data.KawaiiNatRule))
+	kawaii, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, kawaiiId).Kawaii(*kawaii).Execute()
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	data.ID = types.StringValue(id)
+	if idx := kawaiiNatRuleIndex(kawaii, id); idx >= 0 {
+		data.KawaiiNatRule = kawaiiModelToNatRule(&kawaii.Dnat[idx]) // read back resulting object
+	}
+	r.Data.IDs.invalidate(KawaiiResourceName)
+	tflog.Trace(ctx, "created Kawaii NAT rule resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KawaiiNatRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *KawaiiNatRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+
+	// imported identifiers may not be normalized
+	protocol, ports, _ := strings.Cut(data.ID.ValueString(), ":")
+	data.ID = types.StringValue(kawaiiNatRuleID(protocol, ports))
+
+	// rule has been removed out of Terraform
+	idx := kawaiiNatRuleIndex(kawaii, data.ID.ValueString())
+	if idx < 0 {
+		tflog.Warn(ctx, "Kawaii NAT rule no longer exists, removing from state", map[string]any{
+			"kawaii": data.KawaiiID.ValueString(),
+			"rule":   data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.KawaiiNatRule = kawaiiModelToNatRule(&kawaii.Dnat[idx])
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KawaiiNatRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *KawaiiNatRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	unlock := r.Data.Locks.lock(KawaiiResourceName, kawaiiId)
+	defer unlock()
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+
+	// replace existing rule, or restore it if removed out of Terraform
+	m := kawaiiNatRuleModel(&data.KawaiiNatRule)
+	idx := kawaiiNatRuleIndex(kawaii, data.ID.ValueString())
+	if idx < 0 {
+		kawaii.Dnat = append(kawaii.Dnat, m)
+	} else {
+		kawaii.Dnat[idx] = m
+	}
+	kawaii, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, kawaiiId).Kawaii(*kawaii).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	if idx = kawaiiNatRuleIndex(kawaii, data.ID.ValueString()); idx >= 0 {
+		data.KawaiiNatRule = kawaiiModelToNatRule(&kawaii.Dnat[idx])
+	}
+
+	r.Data.IDs.invalidate(KawaiiResourceName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KawaiiNatRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *KawaiiNatRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Delete(ctx, DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+	unlock := r.Data.Locks.lock(KawaiiResourceName, kawaiiId)
+	defer unlock()
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+
+	idx := kawaiiNatRuleIndex(kawaii, data.ID.ValueString())
+	if idx < 0 {
+		tflog.Trace(ctx, "Already deleted "+data.ID.ValueString())
+		return
+	}
+	kawaii.Dnat = slices.Delete(kawaii.Dnat, idx, idx+1)
+	_, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, kawaiiId).Kawaii(*kawaii).Execute()
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+	r.Data.IDs.invalidate(KawaiiResourceName)
+	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	KawaiiReadyDataSourceName = "kawaii_ready"
+
+	KawaiiReadyDefaultTimeout = 10 * time.Minute
+	KawaiiReadyPollInterval   = 10 * time.Second
+
+	KawaiiReadyErrTimeout = "Kawaii not ready"
+)
+
+type KawaiiReadyDataSourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	Kawaii     types.String   `tfsdk:"kawaii"`
+	PublicIPs  types.List     `tfsdk:"public_ips"`
+	PrivateIPs types.List     `tfsdk:"private_ips"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+var _ datasource.DataSource = &KawaiiReadyDataSource{}
+var _ datasource.DataSourceWithConfigure = &KawaiiReadyDataSource{}
+
+func NewKawaiiReadyDataSource() datasource.DataSource {
+	return &KawaiiReadyDataSource{}
+}
+
+type KawaiiReadyDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *KawaiiReadyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, KawaiiReadyDataSourceName)
+}
+
+func (d *KawaiiReadyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *KawaiiReadyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Waits for a Kawaii to be ready, i.e. to exist and have public and private virtual IPs assigned in all of its zones, up to the read timeout (defaults to %s). Lets a workspace depend on a Kawaii provisioned by another one.", KawaiiReadyDefaultTimeout),
+		Attributes: map[string]schema.Attribute{
+			KeyID: schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: DataSourceIdDescription,
+			},
+			KeyKawaii: schema.StringAttribute{
+				MarkdownDescription: "Kawaii name or ID to wait for",
+				Required:            true,
+			},
+			KeyPublicIPs: schema.ListAttribute{
+				MarkdownDescription: "Kawaii public virtual IPs, once ready",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			KeyPrivateIPs: schema.ListAttribute{
+				MarkdownDescription: "Kawaii private virtual IPs, once ready",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			KeyTimeouts: timeouts.AttributesWithOpts(ctx, timeouts.Opts{
+				ReadDescription: KawaiiReadyDefaultTimeout.String(),
+			}),
+		},
+	}
+}
+
+// a Kawaii is ready once it got public and private virtual IPs assigned in
+// all of its zones
+func kawaiiReady(k *sdk.Kawaii) bool {
+	if k == nil || k.Netip == nil || len(k.Netip.Zones) == 0 {
+		return false
+	}
+	if len(k.Netip.Public) == 0 || len(k.Netip.Private) == 0 {
+		return false
+	}
+	for _, z := range k.Netip.Zones {
+		if z.Public == "" || z.Private == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// returns the Kawaii if ready, nil otherwise (including if not created yet),
+// any other API failure (e.g. authentication) being returned straight away
+func (d *KawaiiReadyDataSource) poll(ctx context.Context, id string) (*sdk.Kawaii, error) {
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, d.Data, id)
+	if err != nil {
+		// lookup doesn't tell unknown Kawaii from failed listing
+		_, httpResp, err := d.Data.K.KawaiiAPI.ListKawaiis(ctx).Execute()
+		if err != nil && !errorIsNotFound(httpResp, err) {
+			return nil, err
+		}
+		return nil, nil
+	}
+	kawaii, httpResp, err := d.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if errorIsNotFound(httpResp, err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !kawaiiReady(kawaii) {
+		return nil, nil
+	}
+	return kawaii, nil
+}
+
+func (d *KawaiiReadyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KawaiiReadyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, KawaiiReadyDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// provider's lock is only held while polling, not while waiting
+	var kawaii *sdk.Kawaii
+	for {
+		var err error
+		kawaii, err = d.poll(ctx, data.Kawaii.ValueString())
+		if err != nil && ctx.Err() == nil {
+			errorDataSourceReadGeneric(resp, err)
+			return
+		}
+		if kawaii != nil {
+			break
+		}
+		tflog.Debug(ctx, "waiting for Kawaii to be ready", map[string]any{
+			"kawaii": data.Kawaii.ValueString(),
+		})
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(KawaiiReadyErrTimeout, fmt.Sprintf("Kawaii %s is still not ready after %s", data.Kawaii.ValueString(), timeout))
+			return
+		case <-time.After(KawaiiReadyPollInterval):
+		}
+	}
+
+	data.ID = types.StringPointerValue(kawaii.Id)
+	data.PublicIPs, diags = types.ListValueFrom(ctx, types.StringType, kawaii.Netip.Public)
+	resp.Diagnostics.Append(diags...)
+	data.PrivateIPs, diags = types.ListValueFrom(ctx, types.StringType, kawaii.Netip.Private)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestKawaiiReadyPoll(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		kawaiis []string
+		wantErr bool
+	}{
+		{name: "not created yet", status: http.StatusNotFound, kawaiis: []string{}},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: true},
+		{name: "server failure", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/api/v1/kawaii" && tt.kawaiis != nil {
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(tt.kawaiis)
+					return
+				}
+				w.WriteHeader(tt.status)
+			})
+			d := &KawaiiReadyDataSource{Data: &KowabungaProviderData{K: k, Mutex: &sync.Mutex{}, IDs: newIDCache(IDCacheDefaultTTL)}}
+
+			kawaii, err := d.poll(context.Background(), "gw")
+			if kawaii != nil {
+				t.Errorf("got Kawaii %v, want none", kawaii)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error: %t", err, tt.wantErr)
+			}
+		})
+	}
+}
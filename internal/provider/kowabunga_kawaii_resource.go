@@ -8,12 +8,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"slices"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -38,8 +41,23 @@ const (
 	KawaiiDefaultValueDestination   = "0.0.0.0/0"
 )
 
+// firewall rules turned from lists into sets, so that ordering is irrelevant
+var kawaiiChangedAttributes = []deprecatedAttribute{
+	{
+		Path:   path.Root(KeyIngressRules),
+		Since:  "0.57.0",
+		Change: "`" + KeyIngressRules + "` turned from a list into a set, its elements can no longer be referenced by index",
+	},
+	{
+		Path:   path.Root(KeyEgressRules),
+		Since:  "0.57.0",
+		Change: "`" + KeyEgressRules + "` turned from a list into a set, its elements can no longer be referenced by index",
+	},
+}
+
 var _ resource.Resource = &KawaiiResource{}
 var _ resource.ResourceWithImportState = &KawaiiResource{}
+var _ resource.ResourceWithModifyPlan = &KawaiiResource{}
 
 func NewKawaiiResource() resource.Resource {
 	return &KawaiiResource{}
@@ -57,9 +75,9 @@ type KawaiiResourceModel struct {
 	Region   types.String   `tfsdk:"region"`
 
 	NetworkCfg   types.Object `tfsdk:"netcfg"`        // read-only
-	IngressRules types.List   `tfsdk:"ingress_rules"` // KawaiiIngressRule
+	IngressRules types.Set    `tfsdk:"ingress_rules"` // KawaiiIngressRule
 	EgressPolicy types.String `tfsdk:"egress_policy"`
-	EgressRules  types.List   `tfsdk:"egress_rules"` // KawaiiEgressRule
+	EgressRules  types.Set    `tfsdk:"egress_rules"` // KawaiiEgressRule
 	NatRules     types.List   `tfsdk:"nat_rules"`    // KawaiiNatRule
 	VpcPeerings  types.List   `tfsdk:"vpc_peerings"` // KawaiiVpcPeering
 }
@@ -77,26 +95,26 @@ type KawaiiNetworkZoneConfig struct {
 }
 
 type KawaiiIngressRule struct {
-	Source   types.String `tfsdk:"source"`
-	Protocol types.String `tfsdk:"protocol"`
-	Ports    types.String `tfsdk:"ports"`
+	Source   NetworkAddressValue `tfsdk:"source"`
+	Protocol types.String        `tfsdk:"protocol"`
+	Ports    PortRangesValue     `tfsdk:"ports"`
 }
 
 type KawaiiEgressRule struct {
-	Destination types.String `tfsdk:"destination"`
-	Protocol    types.String `tfsdk:"protocol"`
-	Ports       types.String `tfsdk:"ports"`
+	Destination NetworkAddressValue `tfsdk:"destination"`
+	Protocol    types.String        `tfsdk:"protocol"`
+	Ports       PortRangesValue     `tfsdk:"ports"`
 }
 
 type KawaiiForwardRule struct {
-	Protocol types.String `tfsdk:"protocol"`
-	Ports    types.String `tfsdk:"ports"`
+	Protocol types.String    `tfsdk:"protocol"`
+	Ports    PortRangesValue `tfsdk:"ports"`
 }
 
 type KawaiiNatRule struct {
-	Destination types.String `tfsdk:"destination"`
-	Protocol    types.String `tfsdk:"protocol"`
-	Ports       types.String `tfsdk:"ports"`
+	Destination types.String    `tfsdk:"destination"`
+	Protocol    types.String    `tfsdk:"protocol"`
+	Ports       PortRangesValue `tfsdk:"ports"`
 }
 
 type KawaiiVpcPeering struct {
@@ -193,73 +211,29 @@ func (r *KawaiiResource) SchemaNetworkConfig() schema.SingleNestedAttribute {
 	}
 }
 
-func (r *KawaiiResource) SchemaIngressRules() schema.ListNestedAttribute {
-	return schema.ListNestedAttribute{
-		MarkdownDescription: "The Kawaii public firewall list of ingress rules. Kawaii default policy is to drop all incoming traffic, including ICMP. Specified ruleset will be explicitly accepted.",
+func (r *KawaiiResource) SchemaIngressRules() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "The Kawaii public firewall set of ingress rules (order is irrelevant). Kawaii default policy is to drop all incoming traffic, including ICMP. Specified ruleset will be explicitly accepted. " + kawaiiChangedAttributes[0].Message(),
 		Optional:            true,
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
-				KeySource: schema.StringAttribute{
-					MarkdownDescription: "The source IP or CIDR to accept public traffic from (defaults to 0.0.0.0/0).",
-					Optional:            true,
-					Computed:            true,
-					Default:             stringdefault.StaticString(KawaiiDefaultValueSource),
-					Validators: []validator.String{
-						&stringNetworkAddressValidator{},
-					},
-				},
-				KeyProtocol: schema.StringAttribute{
-					MarkdownDescription: "The transport layer protocol to accept public traffic from (defaults to 'tcp').",
-					Optional:            true,
-					Computed:            true,
-					Default:             stringdefault.StaticString(KawaiiDefaultValueProtocol),
-					Validators: []validator.String{
-						&stringNetworkProtocolValidator{},
-					},
-				},
-				KeyPorts: schema.StringAttribute{
-					MarkdownDescription: "The port (or list of ports) to accept public traffic from. Ranges are accepted. Format is a-b,c-d (e.g. 443; 22,80,443; 80,443,3000-3005).",
-					Required:            true,
-					Validators: []validator.String{
-						&stringNetworkPortRangesValidator{},
-					},
-				},
+				KeySource:   resourceNetworkAddressAttribute("The source IP or CIDR to accept public traffic from", KawaiiDefaultValueSource),
+				KeyProtocol: resourceNetworkRuleProtocolAttribute("to accept public traffic from", KawaiiDefaultValueProtocol),
+				KeyPorts:    resourceNetworkRulePortRangesAttribute("to accept public traffic on"),
 			},
 		},
 	}
 }
 
-func (r *KawaiiResource) SchemaEgressRules() schema.ListNestedAttribute {
-	return schema.ListNestedAttribute{
-		MarkdownDescription: "Kawaii public firewall list of egress rules. Kawaii default policy is to accept all outgoing traffic, including ICMP. Specified ruleset will be explicitly dropped if egress_policy is set to accept, and explicitly accepted if egress policy is set to drop.",
+func (r *KawaiiResource) SchemaEgressRules() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Kawaii public firewall set of egress rules (order is irrelevant). Kawaii default policy is to accept all outgoing traffic, including ICMP. Specified ruleset will be explicitly dropped if egress_policy is set to accept, and explicitly accepted if egress policy is set to drop. " + kawaiiChangedAttributes[1].Message(),
 		Optional:            true,
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
-				KeyDestination: schema.StringAttribute{
-					MarkdownDescription: "The destination IP or CIDR to accept/drop public traffic to (defaults to 0.0.0.0/0) ",
-					Optional:            true,
-					Computed:            true,
-					Default:             stringdefault.StaticString(KawaiiDefaultValueDestination),
-					Validators: []validator.String{
-						&stringNetworkAddressValidator{},
-					},
-				},
-				KeyProtocol: schema.StringAttribute{
-					MarkdownDescription: "The transport layer protocol to accept/drop public traffic to (defaults to 'tcp')",
-					Optional:            true,
-					Computed:            true,
-					Default:             stringdefault.StaticString(KawaiiDefaultValueProtocol),
-					Validators: []validator.String{
-						&stringNetworkProtocolValidator{},
-					},
-				},
-				KeyPorts: schema.StringAttribute{
-					MarkdownDescription: "The port (or list of ports) to forward public traffic from. Ranges are accepted. Format is a-b,c-d (e.g. 443; 22,80,443; 80,443,3000-3005).",
-					Required:            true,
-					Validators: []validator.String{
-						&stringNetworkPortRangesValidator{},
-					},
-				},
+				KeyDestination: resourceNetworkAddressAttribute("The destination IP or CIDR to accept/drop public traffic to", KawaiiDefaultValueDestination),
+				KeyProtocol:    resourceNetworkRuleProtocolAttribute("to accept/drop public traffic to", KawaiiDefaultValueProtocol),
+				KeyPorts:       resourceNetworkRulePortRangesAttribute("to accept/drop public traffic to"),
 			},
 		},
 	}
@@ -268,29 +242,15 @@ func (r *KawaiiResource) SchemaEgressRules() schema.ListNestedAttribute {
 func (r *KawaiiResource) SchemaForwardRule() schema.NestedAttributeObject {
 	return schema.NestedAttributeObject{
 		Attributes: map[string]schema.Attribute{
-			KeyProtocol: schema.StringAttribute{
-				MarkdownDescription: "The transport layer protocol to forward public traffic to (defaults to 'tcp')",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(KawaiiDefaultValueProtocol),
-				Validators: []validator.String{
-					&stringNetworkProtocolValidator{},
-				},
-			},
-			KeyPorts: schema.StringAttribute{
-				MarkdownDescription: "The port (or list of ports) to forward public traffic from. Ranges are accepted. Format is a-b,c-d (e.g. 443; 22,80,443; 80,443,3000-3005).",
-				Required:            true,
-				Validators: []validator.String{
-					&stringNetworkPortRangesValidator{},
-				},
-			},
+			KeyProtocol: resourceNetworkProtocolAttribute("of forwarded VPC traffic", KawaiiDefaultValueProtocol),
+			KeyPorts:    resourceNetworkPortRangesAttribute("to forward VPC traffic to"),
 		},
 	}
 }
 
 func (r *KawaiiResource) SchemaNatRules() schema.ListNestedAttribute {
 	return schema.ListNestedAttribute{
-		MarkdownDescription: "Kawaii list of NAT forwarding rules. Kawaii will forward public Internet traffic from all public virtual IPs to requested private subnet IP addresses.",
+		MarkdownDescription: fmt.Sprintf("Kawaii list of NAT forwarding rules. Kawaii will forward public Internet traffic from all public virtual IPs to requested private subnet IP addresses. Only listed rules are managed: other ones (e.g. from `kowabunga_%s` resources) are left untouched.", KawaiiNatRuleResourceName),
 		Optional:            true,
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
@@ -298,22 +258,8 @@ func (r *KawaiiResource) SchemaNatRules() schema.ListNestedAttribute {
 					MarkdownDescription: "Target private IP address to forward public traffic to.",
 					Required:            true,
 				},
-				KeyProtocol: schema.StringAttribute{
-					MarkdownDescription: "The transport layer protocol to forward public traffic to (defaults to 'tcp')",
-					Optional:            true,
-					Computed:            true,
-					Default:             stringdefault.StaticString(KawaiiDefaultValueProtocol),
-					Validators: []validator.String{
-						&stringNetworkProtocolValidator{},
-					},
-				},
-				KeyPorts: schema.StringAttribute{
-					MarkdownDescription: "The port (or list of ports) to forward public traffic from. Ranges are accepted. Format is a-b,c-d (e.g. 443; 22,80,443; 80,443,3000-3005).",
-					Required:            true,
-					Validators: []validator.String{
-						&stringNetworkPortRangesValidator{},
-					},
-				},
+				KeyProtocol: resourceNetworkProtocolAttribute("to forward public traffic to", KawaiiDefaultValueProtocol),
+				KeyPorts:    resourceNetworkPortRangesAttribute("to forward public traffic from"),
 			},
 		},
 	}
@@ -321,7 +267,7 @@ func (r *KawaiiResource) SchemaNatRules() schema.ListNestedAttribute {
 
 func (r *KawaiiResource) SchemaVpcPeerings() schema.ListNestedAttribute {
 	return schema.ListNestedAttribute{
-		MarkdownDescription: "Kawaii list of Kowabunga private VPC subnet peering rules.",
+		MarkdownDescription: fmt.Sprintf("Kawaii list of Kowabunga private VPC subnet peering rules. Only listed peerings are managed: other ones (e.g. from `kowabunga_%s` resources) are left untouched.", KawaiiVpcPeeringResourceName),
 		Optional:            true,
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
@@ -426,6 +372,14 @@ func (r *KawaiiResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	maps.Copy(resp.Schema.Attributes, resourceAttributesWithoutName(&ctx))
 }
 
+// reports changed attributes usage
+func (r *KawaiiResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.Data == nil {
+		return
+	}
+	deprecationWarnings(ctx, r.Data.Deprecations, KawaiiResourceName, req.Config, kawaiiChangedAttributes, &resp.Diagnostics)
+}
+
 //////////////////////////////////////////////////////////////
 // converts kawaii from Terraform model to Kowabunga API model //
 //////////////////////////////////////////////////////////////
@@ -502,6 +456,60 @@ func kawaiiFirewallModel(ctx *context.Context, d *KawaiiResourceModel) *sdk.Kawa
 	return &fwModel
 }
 
+// returns a NAT rule identifier, e.g. tcp:80,443
+func kawaiiNatRuleID(protocol, ports string) string {
+	if normalized, ok := portRangesNormalize(ports); ok {
+		ports = normalized
+	}
+	return protocol + ":" + ports
+}
+
+// returns a Kowabunga API model NAT rule identifier
+func kawaiiDNatRuleID(rule *sdk.KawaiiDNatRule) string {
+	protocol := KawaiiDefaultValueProtocol
+	if rule.Protocol != nil {
+		protocol = *rule.Protocol
+	}
+	return kawaiiNatRuleID(protocol, rule.Ports)
+}
+
+// returns NAT rules to be applied, i.e. planned ones plus actual ones which
+// aren't managed inline (e.g. by kawaii_nat_rule resources), the ones which
+// were (as per prior state) but aren't planned anymore being removed
+func kawaiiNatRulesMerge(planned, prior, actual []sdk.KawaiiDNatRule) []sdk.KawaiiDNatRule {
+	managed := map[string]bool{}
+	for _, rule := range slices.Concat(planned, prior) {
+		managed[kawaiiDNatRuleID(&rule)] = true
+	}
+	rules := slices.Clone(planned)
+	for _, rule := range actual {
+		if !managed[kawaiiDNatRuleID(&rule)] {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// returns actual NAT rules which are managed inline, as per prior state
+func kawaiiNatRulesManaged(prior, actual []sdk.KawaiiDNatRule) []sdk.KawaiiDNatRule {
+	managed := map[string]bool{}
+	for _, rule := range prior {
+		managed[kawaiiDNatRuleID(&rule)] = true
+	}
+	return slices.DeleteFunc(slices.Clone(actual), func(rule sdk.KawaiiDNatRule) bool {
+		return !managed[kawaiiDNatRuleID(&rule)]
+	})
+}
+
+// converts a single NAT rule from Terraform model to Kowabunga API model
+func kawaiiNatRuleModel(rule *KawaiiNatRule) sdk.KawaiiDNatRule {
+	return sdk.KawaiiDNatRule{
+		Destination: rule.Destination.ValueString(),
+		Protocol:    rule.Protocol.ValueStringPointer(),
+		Ports:       rule.Ports.ValueString(),
+	}
+}
+
 func kawaiiNatRulesModel(ctx *context.Context, d *KawaiiResourceModel) []sdk.KawaiiDNatRule {
 	natModel := []sdk.KawaiiDNatRule{}
 
@@ -524,30 +532,27 @@ func kawaiiNatRulesModel(ctx *context.Context, d *KawaiiResourceModel) []sdk.Kaw
 				tflog.Error(*ctx, err.Detail())
 			}
 		}
-		natModel = append(natModel, sdk.KawaiiDNatRule{
-			Destination: rule.Destination.ValueString(),
-			Protocol:    rule.Protocol.ValueStringPointer(),
-			Ports:       rule.Ports.ValueString(),
-		})
+		natModel = append(natModel, kawaiiNatRuleModel(&rule))
 	}
 
 	return natModel
 }
 
-func kawaiiVpcPeeringsModel(ctx *context.Context, d *KawaiiResourceModel) []sdk.KawaiiVpcPeering {
-	vpModel := []sdk.KawaiiVpcPeering{}
-
-	peerings := make([]types.Object, 0, len(d.VpcPeerings.Elements()))
-	diags := d.VpcPeerings.ElementsAs(*ctx, &peerings, false)
-	if diags.HasError() {
-		for _, err := range diags.Errors() {
+// converts a single VPC peering from Terraform model to Kowabunga API model
+func kawaiiVpcPeeringModel(ctx *context.Context, vp *KawaiiVpcPeering) sdk.KawaiiVpcPeering {
+	// ingress rules
+	ingressModel := []sdk.KawaiiVpcForwardRule{}
+	ingressRules := make([]types.Object, 0, len(vp.IngressRules.Elements()))
+	ingressDiags := vp.IngressRules.ElementsAs(*ctx, &ingressRules, false)
+	if ingressDiags.HasError() {
+		for _, err := range ingressDiags.Errors() {
 			tflog.Debug(*ctx, err.Detail())
 		}
 	}
 
-	for _, p := range peerings {
-		vp := KawaiiVpcPeering{}
-		diags := p.As(*ctx, &vp, basetypes.ObjectAsOptions{
+	for _, ir := range ingressRules {
+		rule := KawaiiForwardRule{}
+		diags := ir.As(*ctx, &rule, basetypes.ObjectAsOptions{
 			UnhandledNullAsEmpty:    true,
 			UnhandledUnknownAsEmpty: true,
 		})
@@ -557,68 +562,101 @@ func kawaiiVpcPeeringsModel(ctx *context.Context, d *KawaiiResourceModel) []sdk.
 			}
 		}
 
-		// ingress rules
-		ingressModel := []sdk.KawaiiVpcForwardRule{}
-		ingressRules := make([]types.Object, 0, len(vp.IngressRules.Elements()))
-		ingressDiags := vp.IngressRules.ElementsAs(*ctx, &ingressRules, false)
-		if ingressDiags.HasError() {
-			for _, err := range ingressDiags.Errors() {
-				tflog.Debug(*ctx, err.Detail())
-			}
+		ingressModel = append(ingressModel, sdk.KawaiiVpcForwardRule{
+			Protocol: rule.Protocol.ValueStringPointer(),
+			Ports:    rule.Ports.ValueString(),
+		})
+	}
+
+	// egress rules
+	egressModel := []sdk.KawaiiVpcForwardRule{}
+	egressRules := make([]types.Object, 0, len(vp.EgressRules.Elements()))
+	egressDiags := vp.EgressRules.ElementsAs(*ctx, &egressRules, false)
+	if egressDiags.HasError() {
+		for _, err := range egressDiags.Errors() {
+			tflog.Debug(*ctx, err.Detail())
 		}
+	}
 
-		for _, ir := range ingressRules {
-			rule := KawaiiForwardRule{}
-			diags := ir.As(*ctx, &rule, basetypes.ObjectAsOptions{
-				UnhandledNullAsEmpty:    true,
-				UnhandledUnknownAsEmpty: true,
-			})
-			if diags.HasError() {
-				for _, err := range diags.Errors() {
-					tflog.Error(*ctx, err.Detail())
-				}
+	for _, er := range egressRules {
+		rule := KawaiiForwardRule{}
+		diags := er.As(*ctx, &rule, basetypes.ObjectAsOptions{
+			UnhandledNullAsEmpty:    true,
+			UnhandledUnknownAsEmpty: true,
+		})
+		if diags.HasError() {
+			for _, err := range diags.Errors() {
+				tflog.Error(*ctx, err.Detail())
 			}
-
-			ingressModel = append(ingressModel, sdk.KawaiiVpcForwardRule{
-				Protocol: rule.Protocol.ValueStringPointer(),
-				Ports:    rule.Ports.ValueString(),
-			})
 		}
 
-		// egress rules
-		egressModel := []sdk.KawaiiVpcForwardRule{}
-		egressRules := make([]types.Object, 0, len(vp.EgressRules.Elements()))
-		egressDiags := vp.EgressRules.ElementsAs(*ctx, &egressRules, false)
-		if egressDiags.HasError() {
-			for _, err := range egressDiags.Errors() {
-				tflog.Debug(*ctx, err.Detail())
-			}
+		egressModel = append(egressModel, sdk.KawaiiVpcForwardRule{
+			Protocol: rule.Protocol.ValueStringPointer(),
+			Ports:    rule.Ports.ValueString(),
+		})
+	}
+
+	return sdk.KawaiiVpcPeering{
+		Subnet:  vp.Subnet.ValueString(),
+		Policy:  vp.Policy.ValueStringPointer(),
+		Ingress: ingressModel,
+		Egress:  egressModel,
+	}
+}
+
+// returns VPC peerings to be applied, i.e. planned ones plus actual ones
+// which aren't managed inline (e.g. by kawaii_vpc_peering resources), the
+// ones which were (as per prior state) but aren't planned anymore being
+// removed
+func kawaiiVpcPeeringsMerge(planned, prior, actual []sdk.KawaiiVpcPeering) []sdk.KawaiiVpcPeering {
+	managed := map[string]bool{}
+	for _, vp := range slices.Concat(planned, prior) {
+		managed[vp.Subnet] = true
+	}
+	peerings := slices.Clone(planned)
+	for _, vp := range actual {
+		if !managed[vp.Subnet] {
+			peerings = append(peerings, vp)
 		}
+	}
+	return peerings
+}
 
-		for _, er := range egressRules {
-			rule := KawaiiForwardRule{}
-			diags := er.As(*ctx, &rule, basetypes.ObjectAsOptions{
-				UnhandledNullAsEmpty:    true,
-				UnhandledUnknownAsEmpty: true,
-			})
-			if diags.HasError() {
-				for _, err := range diags.Errors() {
-					tflog.Error(*ctx, err.Detail())
-				}
-			}
+// returns actual VPC peerings which are managed inline, as per prior state
+func kawaiiVpcPeeringsManaged(prior, actual []sdk.KawaiiVpcPeering) []sdk.KawaiiVpcPeering {
+	managed := map[string]bool{}
+	for _, vp := range prior {
+		managed[vp.Subnet] = true
+	}
+	return slices.DeleteFunc(slices.Clone(actual), func(vp sdk.KawaiiVpcPeering) bool {
+		return !managed[vp.Subnet]
+	})
+}
 
-			egressModel = append(egressModel, sdk.KawaiiVpcForwardRule{
-				Protocol: rule.Protocol.ValueStringPointer(),
-				Ports:    rule.Ports.ValueString(),
-			})
+func kawaiiVpcPeeringsModel(ctx *context.Context, d *KawaiiResourceModel) []sdk.KawaiiVpcPeering {
+	vpModel := []sdk.KawaiiVpcPeering{}
+
+	peerings := make([]types.Object, 0, len(d.VpcPeerings.Elements()))
+	diags := d.VpcPeerings.ElementsAs(*ctx, &peerings, false)
+	if diags.HasError() {
+		for _, err := range diags.Errors() {
+			tflog.Debug(*ctx, err.Detail())
 		}
+	}
 
-		vpModel = append(vpModel, sdk.KawaiiVpcPeering{
-			Subnet:  vp.Subnet.ValueString(),
-			Policy:  vp.Policy.ValueStringPointer(),
-			Ingress: ingressModel,
-			Egress:  egressModel,
+	for _, p := range peerings {
+		vp := KawaiiVpcPeering{}
+		diags := p.As(*ctx, &vp, basetypes.ObjectAsOptions{
+			UnhandledNullAsEmpty:    true,
+			UnhandledUnknownAsEmpty: true,
 		})
+		if diags.HasError() {
+			for _, err := range diags.Errors() {
+				tflog.Error(*ctx, err.Detail())
+			}
+		}
+
+		vpModel = append(vpModel, kawaiiVpcPeeringModel(ctx, &vp))
 	}
 
 	return vpModel
@@ -702,9 +740,9 @@ func kawaiiModelToFirewall(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourc
 	// ingress rules
 	ingressRules := []attr.Value{}
 	ingressRuleType := map[string]attr.Type{
-		KeySource:   types.StringType,
+		KeySource:   NetworkAddressType{},
 		KeyProtocol: types.StringType,
-		KeyPorts:    types.StringType,
+		KeyPorts:    PortRangesType{},
 	}
 	for _, ir := range r.Firewall.Ingress {
 		source := KawaiiDefaultValueSource
@@ -716,19 +754,19 @@ func kawaiiModelToFirewall(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourc
 			protocol = *ir.Protocol
 		}
 		r := map[string]attr.Value{
-			KeySource:   types.StringValue(source),
+			KeySource:   NewNetworkAddressValue(source),
 			KeyProtocol: types.StringValue(protocol),
-			KeyPorts:    types.StringValue(ir.Ports),
+			KeyPorts:    NewPortRangesValue(ir.Ports),
 		}
 		object, _ := types.ObjectValue(ingressRuleType, r)
 		ingressRules = append(ingressRules, object)
 	}
 
 	if len(r.Firewall.Ingress) == 0 {
-		d.IngressRules = types.ListNull(types.ObjectType{AttrTypes: ingressRuleType})
+		d.IngressRules = types.SetNull(types.ObjectType{AttrTypes: ingressRuleType})
 	} else {
 
-		d.IngressRules, _ = types.ListValue(types.ObjectType{AttrTypes: ingressRuleType}, ingressRules)
+		d.IngressRules, _ = types.SetValue(types.ObjectType{AttrTypes: ingressRuleType}, ingressRules)
 	}
 
 	// egress policy
@@ -741,9 +779,9 @@ func kawaiiModelToFirewall(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourc
 	// egress rules
 	egressRules := []attr.Value{}
 	egressRuleType := map[string]attr.Type{
-		KeyDestination: types.StringType,
+		KeyDestination: NetworkAddressType{},
 		KeyProtocol:    types.StringType,
-		KeyPorts:       types.StringType,
+		KeyPorts:       PortRangesType{},
 	}
 	for _, er := range r.Firewall.Egress {
 		destination := KawaiiDefaultValueDestination
@@ -755,160 +793,151 @@ func kawaiiModelToFirewall(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourc
 			protocol = *er.Protocol
 		}
 		r := map[string]attr.Value{
-			KeyDestination: types.StringValue(destination),
+			KeyDestination: NewNetworkAddressValue(destination),
 			KeyProtocol:    types.StringValue(protocol),
-			KeyPorts:       types.StringValue(er.Ports),
+			KeyPorts:       NewPortRangesValue(er.Ports),
 		}
 		object, _ := types.ObjectValue(egressRuleType, r)
 		egressRules = append(egressRules, object)
 	}
 
 	if len(r.Firewall.Egress) == 0 {
-		d.EgressRules = types.ListNull(types.ObjectType{AttrTypes: egressRuleType})
+		d.EgressRules = types.SetNull(types.ObjectType{AttrTypes: egressRuleType})
 	} else {
 
-		d.EgressRules, _ = types.ListValue(types.ObjectType{AttrTypes: egressRuleType}, egressRules)
+		d.EgressRules, _ = types.SetValue(types.ObjectType{AttrTypes: egressRuleType}, egressRules)
 	}
 }
 
-func kawaiiModelToNatRules(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourceModel) {
-	rules := []attr.Value{}
-	ruleType := map[string]attr.Type{
-		KeyDestination: types.StringType,
-		KeyProtocol:    types.StringType,
-		KeyPorts:       types.StringType,
+var kawaiiNatRuleType = map[string]attr.Type{
+	KeyDestination: types.StringType,
+	KeyProtocol:    types.StringType,
+	KeyPorts:       PortRangesType{},
+}
+
+// converts a single NAT rule from Kowabunga API model to Terraform model
+func kawaiiModelToNatRule(rule *sdk.KawaiiDNatRule) KawaiiNatRule {
+	protocol := KawaiiDefaultValueProtocol
+	if rule.Protocol != nil {
+		protocol = *rule.Protocol
+	}
+	return KawaiiNatRule{
+		Destination: types.StringValue(rule.Destination),
+		Protocol:    types.StringValue(protocol),
+		Ports:       NewPortRangesValue(rule.Ports),
 	}
+}
 
-	// empty rules ?
-	if len(r.Dnat) == 0 {
-		d.NatRules = types.ListNull(types.ObjectType{AttrTypes: ruleType})
+func kawaiiModelToNatRules(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourceModel) {
+	// rules are left to kawaii_nat_rule resources when not managed inline
+	if d.NatRules.IsNull() {
 		return
 	}
 
-	for _, rule := range r.Dnat {
-		protocol := KawaiiDefaultValueProtocol
-		if rule.Protocol != nil {
-			protocol = *rule.Protocol
-		}
-		r := map[string]attr.Value{
-			KeyDestination: types.StringValue(rule.Destination),
-			KeyProtocol:    types.StringValue(protocol),
-			KeyPorts:       types.StringValue(rule.Ports),
-		}
-		object, _ := types.ObjectValue(ruleType, r)
+	rules := []attr.Value{}
+	for _, rule := range kawaiiNatRulesManaged(kawaiiNatRulesModel(ctx, d), r.Dnat) {
+		object, _ := types.ObjectValueFrom(*ctx, kawaiiNatRuleType, kawaiiModelToNatRule(&rule))
 		rules = append(rules, object)
 	}
-	d.NatRules, _ = types.ListValue(types.ObjectType{AttrTypes: ruleType}, rules)
+	d.NatRules, _ = types.ListValue(types.ObjectType{AttrTypes: kawaiiNatRuleType}, rules)
 }
 
-func kawaiiModelToVpcPeerings(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourceModel) {
-	vpc := []attr.Value{}
-	vpcType := map[string]attr.Type{
-		KeySubnet: types.StringType,
-		KeyPolicy: types.StringType,
-		KeyIngressRules: types.ListType{
-			ElemType: types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					KeyProtocol: types.StringType,
-					KeyPorts:    types.StringType,
-				},
-			},
-		},
-		KeyEgressRules: types.ListType{
-			ElemType: types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					KeyProtocol: types.StringType,
-					KeyPorts:    types.StringType,
-				},
-			},
-		},
-		KeyNetworkConfig: types.ListType{
-			ElemType: types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					KeyZone:      types.StringType,
-					KeyPrivateIP: types.StringType,
-				},
-			},
-		},
-	}
+var kawaiiForwardRuleType = map[string]attr.Type{
+	KeyProtocol: types.StringType,
+	KeyPorts:    PortRangesType{},
+}
 
-	// empty peerings ?
-	if len(r.VpcPeerings) == 0 {
-		d.VpcPeerings = types.ListNull(types.ObjectType{AttrTypes: vpcType})
-		return
-	}
+var kawaiiVpcPeeringNetworkConfigType = map[string]attr.Type{
+	KeyZone:      types.StringType,
+	KeyPrivateIP: types.StringType,
+}
 
-	fwRuleType := map[string]attr.Type{
-		KeyProtocol: types.StringType,
-		KeyPorts:    types.StringType,
-	}
+var kawaiiVpcPeeringType = map[string]attr.Type{
+	KeySubnet: types.StringType,
+	KeyPolicy: types.StringType,
+	KeyIngressRules: types.ListType{
+		ElemType: types.ObjectType{AttrTypes: kawaiiForwardRuleType},
+	},
+	KeyEgressRules: types.ListType{
+		ElemType: types.ObjectType{AttrTypes: kawaiiForwardRuleType},
+	},
+	KeyNetworkConfig: types.ListType{
+		ElemType: types.ObjectType{AttrTypes: kawaiiVpcPeeringNetworkConfigType},
+	},
+}
 
-	netCfgType := map[string]attr.Type{
-		KeyZone:      types.StringType,
-		KeyPrivateIP: types.StringType,
+// converts a single VPC peering from Kowabunga API model to Terraform model
+func kawaiiModelToVpcPeering(vp *sdk.KawaiiVpcPeering) KawaiiVpcPeering {
+	policy := KawaiiDefaultValueForwardPolicy
+	if vp.Policy != nil {
+		policy = *vp.Policy
 	}
 
-	for _, vp := range r.VpcPeerings {
-		policy := KawaiiDefaultValueForwardPolicy
-		if vp.Policy != nil {
-			policy = *vp.Policy
+	// ingress rules
+	ingressRules := []attr.Value{}
+	for _, ir := range vp.Ingress {
+		protocol := KawaiiDefaultValueProtocol
+		if ir.Protocol != nil {
+			protocol = *ir.Protocol
 		}
 
-		// ingress rules
-		ingressRules := []attr.Value{}
-		for _, ir := range vp.Ingress {
-			protocol := KawaiiDefaultValueProtocol
-			if ir.Protocol != nil {
-				protocol = *ir.Protocol
-			}
-
-			rule := map[string]attr.Value{
-				KeyProtocol: types.StringValue(protocol),
-				KeyPorts:    types.StringValue(ir.Ports),
-			}
-			object, _ := types.ObjectValue(fwRuleType, rule)
-			ingressRules = append(ingressRules, object)
+		rule := map[string]attr.Value{
+			KeyProtocol: types.StringValue(protocol),
+			KeyPorts:    NewPortRangesValue(ir.Ports),
 		}
+		object, _ := types.ObjectValue(kawaiiForwardRuleType, rule)
+		ingressRules = append(ingressRules, object)
+	}
 
-		// egress rules
-		egressRules := []attr.Value{}
-		for _, er := range vp.Egress {
-			protocol := KawaiiDefaultValueProtocol
-			if er.Protocol != nil {
-				protocol = *er.Protocol
-			}
-
-			rule := map[string]attr.Value{
-				KeyProtocol: types.StringValue(protocol),
-				KeyPorts:    types.StringValue(er.Ports),
-			}
-			object, _ := types.ObjectValue(fwRuleType, rule)
-			egressRules = append(egressRules, object)
+	// egress rules
+	egressRules := []attr.Value{}
+	for _, er := range vp.Egress {
+		protocol := KawaiiDefaultValueProtocol
+		if er.Protocol != nil {
+			protocol = *er.Protocol
 		}
 
-		// network config
-		netCfg := []attr.Value{}
-		for _, cfg := range vp.Netip {
-			v := map[string]attr.Value{
-				KeyZone:      types.StringValue(cfg.Zone),
-				KeyPrivateIP: types.StringValue(cfg.Private),
-			}
-			object, _ := types.ObjectValue(netCfgType, v)
-			netCfg = append(netCfg, object)
+		rule := map[string]attr.Value{
+			KeyProtocol: types.StringValue(protocol),
+			KeyPorts:    NewPortRangesValue(er.Ports),
 		}
+		object, _ := types.ObjectValue(kawaiiForwardRuleType, rule)
+		egressRules = append(egressRules, object)
+	}
 
-		r := map[string]attr.Value{
-			KeySubnet: types.StringValue(vp.Subnet),
-			KeyPolicy: types.StringValue(policy),
+	// network config
+	netCfg := []attr.Value{}
+	for _, cfg := range vp.Netip {
+		v := map[string]attr.Value{
+			KeyZone:      types.StringValue(cfg.Zone),
+			KeyPrivateIP: types.StringValue(cfg.Private),
 		}
-		r[KeyIngressRules], _ = types.ListValue(types.ObjectType{AttrTypes: fwRuleType}, ingressRules)
-		r[KeyEgressRules], _ = types.ListValue(types.ObjectType{AttrTypes: fwRuleType}, egressRules)
-		r[KeyNetworkConfig], _ = types.ListValue(types.ObjectType{AttrTypes: netCfgType}, netCfg)
+		object, _ := types.ObjectValue(kawaiiVpcPeeringNetworkConfigType, v)
+		netCfg = append(netCfg, object)
+	}
+
+	d := KawaiiVpcPeering{
+		Subnet: types.StringValue(vp.Subnet),
+		Policy: types.StringValue(policy),
+	}
+	d.IngressRules, _ = types.ListValue(types.ObjectType{AttrTypes: kawaiiForwardRuleType}, ingressRules)
+	d.EgressRules, _ = types.ListValue(types.ObjectType{AttrTypes: kawaiiForwardRuleType}, egressRules)
+	d.NetworkCfg, _ = types.ListValue(types.ObjectType{AttrTypes: kawaiiVpcPeeringNetworkConfigType}, netCfg)
+	return d
+}
 
-		object, _ := types.ObjectValue(vpcType, r)
+func kawaiiModelToVpcPeerings(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourceModel) {
+	// peerings are left to kawaii_vpc_peering resources when not managed inline
+	if d.VpcPeerings.IsNull() {
+		return
+	}
+
+	vpc := []attr.Value{}
+	for _, vp := range kawaiiVpcPeeringsManaged(kawaiiVpcPeeringsModel(ctx, d), r.VpcPeerings) {
+		object, _ := types.ObjectValueFrom(*ctx, kawaiiVpcPeeringType, kawaiiModelToVpcPeering(&vp))
 		vpc = append(vpc, object)
 	}
-	d.VpcPeerings, _ = types.ListValue(types.ObjectType{AttrTypes: vpcType}, vpc)
+	d.VpcPeerings, _ = types.ListValue(types.ObjectType{AttrTypes: kawaiiVpcPeeringType}, vpc)
 }
 
 func kawaiiModelToResource(ctx *context.Context, r *sdk.Kawaii, d *KawaiiResourceModel) {
@@ -968,6 +997,7 @@ func (r *KawaiiResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	data.ID = types.StringPointerValue(kawaii.Id)
 	kawaiiModelToResource(&ctx, kawaii, data) // read back resulting object
+	r.Data.IDs.invalidate(KawaiiResourceName)
 	tflog.Trace(ctx, "created Kawaii resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -1001,8 +1031,9 @@ func (r *KawaiiResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *KawaiiResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *KawaiiResourceModel
+	var data, state *KawaiiResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -1018,13 +1049,26 @@ func (r *KawaiiResource) Update(ctx context.Context, req resource.UpdateRequest,
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
+	// NAT rules and peerings may be concurrently updated by standalone resources
+	unlock := r.Data.Locks.lock(KawaiiResourceName, data.ID.ValueString())
+	defer unlock()
+
+	// keep NAT rules and peerings which aren't managed inline
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, data.ID.ValueString()).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
 	m := kawaiiResourceToModel(&ctx, data)
-	_, _, err := r.Data.K.KawaiiAPI.UpdateKawaii(ctx, data.ID.ValueString()).Kawaii(m).Execute()
+	m.Dnat = kawaiiNatRulesMerge(m.Dnat, kawaiiNatRulesModel(&ctx, state), kawaii.Dnat)
+	m.VpcPeerings = kawaiiVpcPeeringsMerge(m.VpcPeerings, kawaiiVpcPeeringsModel(&ctx, state), kawaii.VpcPeerings)
+	_, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, data.ID.ValueString()).Kawaii(m).Execute()
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
 	}
 
+	r.Data.IDs.invalidate(KawaiiResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -1051,5 +1095,6 @@ func (r *KawaiiResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(KawaiiResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+)
+
+func natRuleIDs(rules []sdk.KawaiiDNatRule) []string {
+	ids := []string{}
+	for _, rule := range rules {
+		ids = append(ids, kawaiiDNatRuleID(&rule))
+	}
+	return ids
+}
+
+func TestKawaiiNatRulesMerge(t *testing.T) {
+	udp := "udp"
+	planned := []sdk.KawaiiDNatRule{
+		{Destination: "10.0.0.1", Ports: "80,443"},
+		{Destination: "10.0.0.2", Protocol: &udp, Ports: "53"},
+	}
+	prior := []sdk.KawaiiDNatRule{
+		{Destination: "10.0.0.1", Ports: "443,80"},
+		{Destination: "10.0.0.3", Ports: "22"},
+	}
+	actual := []sdk.KawaiiDNatRule{
+		{Destination: "10.0.0.1", Ports: "443,80"},
+		{Destination: "10.0.0.3", Ports: "22"},
+		{Destination: "10.0.0.4", Ports: "8080"}, // e.g. from kawaii_nat_rule
+	}
+
+	got := natRuleIDs(kawaiiNatRulesMerge(planned, prior, actual))
+	want := []string{"tcp:80,443", "udp:53", "tcp:8080"}
+	if !slices.Equal(got, want) {
+		t.Errorf("merged rules: got %v, want %v", got, want)
+	}
+
+	got = natRuleIDs(kawaiiNatRulesManaged(prior, actual))
+	want = []string{"tcp:80,443", "tcp:22"}
+	if !slices.Equal(got, want) {
+		t.Errorf("managed rules: got %v, want %v", got, want)
+	}
+}
+
+func TestKawaiiVpcPeeringsMerge(t *testing.T) {
+	planned := []sdk.KawaiiVpcPeering{{Subnet: "a"}}
+	prior := []sdk.KawaiiVpcPeering{{Subnet: "a"}, {Subnet: "b"}}
+	actual := []sdk.KawaiiVpcPeering{{Subnet: "b"}, {Subnet: "c"}}
+
+	subnets := func(peerings []sdk.KawaiiVpcPeering) []string {
+		s := []string{}
+		for _, vp := range peerings {
+			s = append(s, vp.Subnet)
+		}
+		return s
+	}
+
+	got := subnets(kawaiiVpcPeeringsMerge(planned, prior, actual))
+	if want := []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("merged peerings: got %v, want %v", got, want)
+	}
+	got = subnets(kawaiiVpcPeeringsManaged(prior, actual))
+	if want := []string{"b"}; !slices.Equal(got, want) {
+		t.Errorf("managed peerings: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,331 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	KawaiiVpcPeeringResourceName = "kawaii_vpc_peering"
+
+	KawaiiVpcPeeringErrAlreadyExists = "Kawaii already peers with subnet"
+)
+
+var _ resource.Resource = &KawaiiVpcPeeringResource{}
+var _ resource.ResourceWithImportState = &KawaiiVpcPeeringResource{}
+var _ resource.ResourceWithModifyPlan = &KawaiiVpcPeeringResource{}
+
+func NewKawaiiVpcPeeringResource() resource.Resource {
+	return &KawaiiVpcPeeringResource{}
+}
+
+type KawaiiVpcPeeringResource struct {
+	Data *KowabungaProviderData
+}
+
+type KawaiiVpcPeeringResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	KawaiiID types.String   `tfsdk:"kawaii"`
+	KawaiiVpcPeering
+}
+
+func (r *KawaiiVpcPeeringResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resourceMetadata(req, resp, KawaiiVpcPeeringResourceName)
+}
+
+func (r *KawaiiVpcPeeringResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resourceImportStateWithParent(ctx, req, resp, KeyKawaii)
+}
+
+func (r *KawaiiVpcPeeringResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.Data = resourceConfigure(req, resp)
+}
+
+func (r *KawaiiVpcPeeringResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// same peering attributes as Kawaii inline ones
+	attributes := maps.Clone((&KawaiiResource{}).SchemaVpcPeerings().NestedObject.Attributes)
+	attributes[KeyID] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Resource object internal identifier (i.e. peered Kowabunga Subnet ID)",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes[KeyKawaii] = schema.StringAttribute{
+		MarkdownDescription: "Associated Kawaii name or ID. Changing it to another Kawaii forces a new resource to be created.",
+		Required:            true,
+	}
+	attributes[KeySubnet] = schema.StringAttribute{
+		MarkdownDescription: "Kowabunga Subnet name or ID to be peered with (subnet local IP addresses will be automatically assigned to Kawaii instances). Changing it to another subnet forces a new resource to be created.",
+		Required:            true,
+	}
+	attributes[KeyTimeouts] = timeouts.Attributes(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: DefaultCreateTimeout.String(),
+		ReadDescription:   DefaultReadTimeout.String(),
+		UpdateDescription: DefaultUpdateTimeout.String(),
+		DeleteDescription: DefaultDeleteTimeout.String(),
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a Kawaii VPC peering, i.e. a single `vpc_peerings` entry of a `kowabunga_%s` resource, which can then be managed from another workspace than the Kawaii one. Kawaii inline `vpc_peerings` leave unlisted peerings untouched, the very same subnet must however not be declared both ways. Import with `<kawaii>/<subnet ID>`.\n\n~> Peerings being part of the Kawaii object, each change reads and rewrites the whole Kawaii. Changes from a single Terraform run are serialized, but **concurrent applies from several workspaces on the same Kawaii will overwrite each other's changes**: make sure such applies are never run concurrently.", KawaiiResourceName),
+		Attributes:          attributes,
+	}
+}
+
+// returns the index of the peering with a given subnet, -1 if none
+func kawaiiVpcPeeringIndex(k *sdk.Kawaii, subnetId string) int {
+	return slices.IndexFunc(k.VpcPeerings, func(vp sdk.KawaiiVpcPeering) bool {
+		return vp.Subnet == subnetId
+	})
+}
+
+// peered subnet is kept as configured (i.e. possibly by name)
+func kawaiiVpcPeeringModelToResource(vp *sdk.KawaiiVpcPeering, d *KawaiiVpcPeeringResourceModel) {
+	subnet := d.Subnet
+	d.KawaiiVpcPeering = kawaiiModelToVpcPeering(vp)
+	if !subnet.IsNull() && !subnet.IsUnknown() {
+		d.Subnet = subnet
+	}
+}
+
+// parent references are only replaced when changed to other objects
+func (r *KawaiiVpcPeeringResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourcePlanParentReplace(ctx, r.Data, req, resp, KeyKawaii, getKawaiiID)
+	resourcePlanParentReplace(ctx, r.Data, req, resp, KeySubnet, getSubnetID)
+}
+
+//////////////////////////////
+// Terraform CRUD Functions //
+//////////////////////////////
+
+// peerings are part of the Kawaii object, all changes are read-modify-write
+// ones, under a per-Kawaii lock which holds whatever provider's parallelism
+// setting (but can't prevent concurrent changes from other workspaces)
+
+func (r *KawaiiVpcPeeringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *KawaiiVpcPeeringResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	// find parent kawaii
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	unlock := r.Data.Locks.lock(KawaiiResourceName, kawaiiId)
+	defer unlock()
+
+	// find peered subnet
+	subnetId, err := getSubnetID(ctx, r.Data, data.Subnet.ValueString())
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	if kawaiiVpcPeeringIndex(kawaii, subnetId) >= 0 {
+		resp.Diagnostics.AddError(KawaiiVpcPeeringErrAlreadyExists, fmt.Sprintf("Kawaii %s already peers with subnet %s, import it instead", data.KawaiiID.ValueString(), data.Subnet.ValueString()))
+		return
+	}
+
+	// add a new peering
+	m := kawaiiVpcPeeringModel(&ctx, &data.KawaiiVpcPeering)
+	m.Subnet = subnetId
+	kawaii.VpcPeerings = append(kawaii.VpcPeerings, m)
+	kawaii, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, kawaiiId).Kawaii(*kawaii).Execute()
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+	data.ID = types.StringValue(subnetId)
+	if idx := kawaiiVpcPeeringIndex(kawaii, subnetId); idx >= 0 {
+		kawaiiVpcPeeringModelToResource(&kawaii.VpcPeerings[idx], data) // read back resulting object
+	}
+	r.Data.IDs.invalidate(KawaiiResourceName)
+	tflog.Trace(ctx, "created Kawaii VPC peering resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KawaiiVpcPeeringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *KawaiiVpcPeeringResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorReadGeneric(resp, err)
+		return
+	}
+
+	// peering has been removed out of Terraform
+	idx := kawaiiVpcPeeringIndex(kawaii, data.ID.ValueString())
+	if idx < 0 {
+		tflog.Warn(ctx, "Kawaii VPC peering no longer exists, removing from state", map[string]any{
+			"kawaii": data.KawaiiID.ValueString(),
+			"subnet": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	kawaiiVpcPeeringModelToResource(&kawaii.VpcPeerings[idx], data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KawaiiVpcPeeringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *KawaiiVpcPeeringResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	unlock := r.Data.Locks.lock(KawaiiResourceName, kawaiiId)
+	defer unlock()
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+
+	// replace existing peering, or restore it if removed out of Terraform
+	m := kawaiiVpcPeeringModel(&ctx, &data.KawaiiVpcPeering)
+	m.Subnet = data.ID.ValueString()
+	idx := kawaiiVpcPeeringIndex(kawaii, m.Subnet)
+	if idx < 0 {
+		kawaii.VpcPeerings = append(kawaii.VpcPeerings, m)
+	} else {
+		kawaii.VpcPeerings[idx] = m
+	}
+	kawaii, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, kawaiiId).Kawaii(*kawaii).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	if idx = kawaiiVpcPeeringIndex(kawaii, m.Subnet); idx >= 0 {
+		kawaiiVpcPeeringModelToResource(&kawaii.VpcPeerings[idx], data)
+	}
+
+	r.Data.IDs.invalidate(KawaiiResourceName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KawaiiVpcPeeringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *KawaiiVpcPeeringResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Delete(ctx, DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	kawaiiId, err := getKawaiiID(ctx, r.Data, data.KawaiiID.ValueString())
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+	unlock := r.Data.Locks.lock(KawaiiResourceName, kawaiiId)
+	defer unlock()
+	kawaii, _, err := r.Data.K.KawaiiAPI.ReadKawaii(ctx, kawaiiId).Execute()
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+
+	idx := kawaiiVpcPeeringIndex(kawaii, data.ID.ValueString())
+	if idx < 0 {
+		tflog.Trace(ctx, "Already deleted "+data.ID.ValueString())
+		return
+	}
+	kawaii.VpcPeerings = slices.Delete(kawaii.VpcPeerings, idx, idx+1)
+	_, _, err = r.Data.K.KawaiiAPI.UpdateKawaii(ctx, kawaiiId).Kawaii(*kawaii).Execute()
+	if err != nil {
+		errorDeleteGeneric(resp, err)
+		return
+	}
+	r.Data.IDs.invalidate(KawaiiResourceName)
+	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
+}
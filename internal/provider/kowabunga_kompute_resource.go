@@ -8,19 +8,28 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"time"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -32,10 +41,15 @@ const (
 	KomputeDefaultValueTemplate  = ""
 	KomputeDefaultValueExtraDisk = 0
 	KomputeDefaultValuePublic    = false
+	KomputeDefaultValueDrain     = 0
+
+	KomputeErrLastBackend = "Kompute instance is the last backend of Konvey %s endpoint %s, which can't be left without any: remove endpoint first or disable drain_timeout"
 )
 
 var _ resource.Resource = &KomputeResource{}
 var _ resource.ResourceWithImportState = &KomputeResource{}
+var _ resource.ResourceWithModifyPlan = &KomputeResource{}
+var _ resource.ResourceWithValidateConfig = &KomputeResource{}
 
 func NewKomputeResource() resource.Resource {
 	return &KomputeResource{}
@@ -55,11 +69,16 @@ type KomputeResourceModel struct {
 	Pool      types.String   `tfsdk:"pool"`
 	Template  types.String   `tfsdk:"template"`
 	VCPUs     types.Int64    `tfsdk:"vcpus"`
-	Memory    types.Int64    `tfsdk:"mem"`
-	Disk      types.Int64    `tfsdk:"disk"`
-	ExtraDisk types.Int64    `tfsdk:"extra_disk"`
+	Memory    SizeGBValue    `tfsdk:"mem"`
+	Disk      SizeGBValue    `tfsdk:"disk"`
+	ExtraDisk SizeGBValue    `tfsdk:"extra_disk"`
 	Public    types.Bool     `tfsdk:"public"`
 	IP        types.String   `tfsdk:"ip"`
+	Drain     types.Int64    `tfsdk:"drain_timeout"`
+	State     types.String   `tfsdk:"state"`
+	Reboot    types.String   `tfsdk:"reboot_trigger"`
+	Price     types.Float64  `tfsdk:"price_estimate"` // read-only
+	Currency  types.String   `tfsdk:"currency"`       // read-only
 }
 
 func (r *KomputeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -106,16 +125,25 @@ func (r *KomputeResource) Schema(ctx context.Context, req resource.SchemaRequest
 			KeyMemory: schema.Int64Attribute{
 				MarkdownDescription: "The Kompute instance memory size (expressed in GB)",
 				Required:            true,
+				CustomType:          SizeGBType{},
 			},
 			KeyDisk: schema.Int64Attribute{
-				MarkdownDescription: "The Kompute instance OS disk size (expressed in GB)",
+				MarkdownDescription: "The Kompute instance OS disk size (expressed in GB). Disk can only grow, a size not greater than the actual one (e.g. disk grown beyond it from a larger template) plans no change.",
 				Required:            true,
+				CustomType:          SizeGBType{},
+				PlanModifiers: []planmodifier.Int64{
+					int64GrowOnlyModifier{},
+				},
 			},
 			KeyExtraDisk: schema.Int64Attribute{
-				MarkdownDescription: "The Kompute optional data disk size (expressed in GB, disabled by default, 0 to disable)",
+				MarkdownDescription: "The Kompute optional data disk size (expressed in GB, disabled by default, 0 to disable). Disk can only grow.",
 				Optional:            true,
+				CustomType:          SizeGBType{},
 				Computed:            true,
 				Default:             int64default.StaticInt64(KomputeDefaultValueExtraDisk),
+				PlanModifiers: []planmodifier.Int64{
+					int64GrowOnlyModifier{},
+				},
 			},
 			KeyPublic: schema.BoolAttribute{
 				MarkdownDescription: "Should Kompute instance be exposed over public Internet ? (default: **false**)",
@@ -123,6 +151,17 @@ func (r *KomputeResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:            true,
 				Default:             booldefault.StaticBool(KomputeDefaultValuePublic),
 			},
+			KeyDrainTimeout: schema.Int64Attribute{
+				MarkdownDescription: "Connection draining timeout (expressed in seconds, disabled by default, 0 to disable). When set, Kompute instance is first deregistered from all project's Konvey load-balancers backends before deletion, and connections are given this time to drain. Delete timeout must be larger. Deletion fails rather than leaving an endpoint without any backend. Deregistered backends of Terraform-managed `kowabunga_konvey` resources show up as drift until Kompute is removed from their configuration too.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(KomputeDefaultValueDrain),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			KeyState:         resourcePowerStateAttribute("Kompute instance"),
+			KeyRebootTrigger: resourceRebootTriggerAttribute("Kompute instance"),
 			KeyIP: schema.StringAttribute{
 				MarkdownDescription: "IP (read-only)",
 				Computed:            true,
@@ -130,16 +169,233 @@ func (r *KomputeResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			KeyPriceEstimate: schema.Float64Attribute{
+				MarkdownDescription: "Kompute instance estimated monthly price (read-only). Computed from zone's Kaktus nodes average price per vCPU and GB of memory (node's global prices being split over its over-committed capacity), and storage pool price per GB of disks (region's pools average one if pool is unspecified). Unit prices are read once per Terraform run.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			KeyCurrency: schema.StringAttribute{
+				MarkdownDescription: "Kompute instance estimated monthly price currency (read-only)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
 }
 
+func (r *KomputeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data KomputeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resourceValidateWait(ctx, data.Timeouts, "delete", data.Drain, KeyDrainTimeout, &resp.Diagnostics)
+}
+
+// checks project quotas on creation and re-estimates price at plan time
+// whenever kompute's sizing changes, so that cost deltas show up in plan output
+func (r *KomputeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to estimate on destruction
+	if req.Plan.Raw.IsNull() || r.Data == nil {
+		return
+	}
+
+	var plan KomputeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Zone.IsUnknown() || plan.Pool.IsUnknown() || plan.VCPUs.IsUnknown() || plan.Memory.IsUnknown() || plan.Disk.IsUnknown() || plan.ExtraDisk.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state KomputeResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.Zone.Equal(state.Zone) && plan.Pool.Equal(state.Pool) && plan.VCPUs.Equal(state.VCPUs) && plan.Memory.Equal(state.Memory) && plan.Disk.Equal(state.Disk) && plan.ExtraDisk.Equal(state.ExtraDisk) {
+			return
+		}
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	if req.State.Raw.IsNull() {
+		m := komputeResourceToModel(&plan)
+		planProjectQuotas(ctx, r.Data, plan.Project, komputeQuotaRequest(&m), &resp.Diagnostics)
+	}
+
+	price, currency, err := komputePriceEstimate(ctx, r.Data, &plan)
+	if err != nil {
+		tflog.Warn(ctx, "unable to estimate Kompute price: "+err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(KeyPriceEstimate), price)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(KeyCurrency), currency)...)
+}
+
+// returns the project resources a new Kompute requires
+func komputeQuotaRequest(m *sdk.Kompute) sdk.ProjectResources {
+	instances := int32(1)
+	vcpus := int32(m.Vcpus)
+	storage := m.Disk + m.GetDataDisk()
+	return sdk.ProjectResources{
+		Instances: &instances,
+		Vcpus:     &vcpus,
+		Memory:    &m.Memory,
+		Storage:   &storage,
+	}
+}
+
+// estimates kompute's monthly price from zone's kaktus nodes average price per
+// vCPU and GB of memory, and storage pool (or region's pools average) price
+// per GB of disk
+func komputePriceEstimate(ctx context.Context, data *KowabungaProviderData, d *KomputeResourceModel) (float64, string, error) {
+	zoneId, err := getZoneID(ctx, data, d.Zone.ValueString())
+	if err != nil {
+		return 0, "", err
+	}
+	zone, err := data.Prices.get(ZoneResourceName+"/"+zoneId, func() (unitPrices, error) {
+		return komputeZonePrices(ctx, data, zoneId)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	storage := unitPrices{}
+	if pool := d.Pool.ValueString(); pool != "" {
+		poolId, err := getPoolID(ctx, data, pool)
+		if err != nil {
+			return 0, "", err
+		}
+		storage, err = data.Prices.get(StoragePoolResourceName+"/"+poolId, func() (unitPrices, error) {
+			return komputePoolsPrices(ctx, data, []string{poolId})
+		})
+		if err != nil {
+			return 0, "", err
+		}
+	} else {
+		storage, err = data.Prices.get(ZoneResourceName+"/"+zoneId+"/"+StoragePoolResourceName, func() (unitPrices, error) {
+			regionId, err := getZoneRegionID(ctx, data, zoneId)
+			if err != nil {
+				return unitPrices{}, err
+			}
+			pools, _, err := data.K.RegionAPI.ListRegionStoragePools(ctx, regionId).Execute()
+			if err != nil {
+				return unitPrices{}, err
+			}
+			return komputePoolsPrices(ctx, data, pools)
+		})
+		if err != nil {
+			return 0, "", err
+		}
+	}
+
+	disk := d.Disk.ValueInt64() + d.ExtraDisk.ValueInt64()
+	price := float64(d.VCPUs.ValueInt64())*zone.CPU + float64(d.Memory.ValueInt64())*zone.Memory + float64(disk)*storage.Storage
+	return math.Round(price*100) / 100, zone.Currency, nil
+}
+
+// returns zone's kaktus nodes average price per vCPU and GB of memory
+func komputeZonePrices(ctx context.Context, data *KowabungaProviderData, zoneId string) (unitPrices, error) {
+	kaktuses, _, err := data.K.ZoneAPI.ListZoneKaktuses(ctx, zoneId).Execute()
+	if err != nil {
+		return unitPrices{}, err
+	}
+
+	prices := unitPrices{}
+	var nodes int
+	for _, id := range kaktuses {
+		kaktus, _, err := data.K.KaktusAPI.ReadKaktus(ctx, id).Execute()
+		if err != nil {
+			continue
+		}
+		caps, _, err := data.K.KaktusAPI.ReadKaktusCaps(ctx, id).Execute()
+		if err != nil {
+			continue
+		}
+
+		cpuRatio := int64(KaktusDefaultValueCpuOverCommit)
+		if kaktus.OvercommitCpuRatio != nil {
+			cpuRatio = *kaktus.OvercommitCpuRatio
+		}
+		memRatio := int64(KaktusDefaultValueMemoryOverCommit)
+		if kaktus.OvercommitMemoryRatio != nil {
+			memRatio = *kaktus.OvercommitMemoryRatio
+		}
+		cpus := caps.Cpu.Sockets * caps.Cpu.Cores * caps.Cpu.Threads * cpuRatio
+		mem := caps.Memory / HelperGbToBytes * memRatio
+		if cpus <= 0 || mem <= 0 {
+			continue
+		}
+		prices.CPU += float64(kaktus.CpuCost.GetPrice()) / float64(cpus)
+		prices.Memory += float64(kaktus.MemoryCost.GetPrice()) / float64(mem)
+		prices.Currency = kaktus.CpuCost.GetCurrency()
+		nodes++
+	}
+	if nodes == 0 {
+		return unitPrices{}, fmt.Errorf("%s", ErrorUnknownKaktus)
+	}
+	prices.CPU /= float64(nodes)
+	prices.Memory /= float64(nodes)
+	return prices, nil
+}
+
+// returns storage pools average price per GB
+func komputePoolsPrices(ctx context.Context, data *KowabungaProviderData, poolIds []string) (unitPrices, error) {
+	prices := unitPrices{}
+	var pools int
+	for _, id := range poolIds {
+		pool, _, err := data.K.PoolAPI.ReadStoragePool(ctx, id).Execute()
+		if err != nil {
+			continue
+		}
+		prices.Storage += float64(pool.Cost.GetPrice())
+		prices.Currency = pool.Cost.GetCurrency()
+		pools++
+	}
+	if pools == 0 {
+		return unitPrices{}, fmt.Errorf("%s", ErrorUnknownPool)
+	}
+	prices.Storage /= float64(pools)
+	return prices, nil
+}
+
+// sets kompute's estimated price in state, when unknown or being refreshed
+func (r *KomputeResource) estimatePrice(ctx context.Context, d *KomputeResourceModel, refresh bool) {
+	if !refresh && !d.Price.IsUnknown() && !d.Currency.IsUnknown() {
+		return
+	}
+
+	price, currency, err := komputePriceEstimate(ctx, r.Data, d)
+	if err != nil {
+		tflog.Warn(ctx, "unable to estimate Kompute price: "+err.Error())
+		// keep prior estimate, if any
+		if d.Price.IsUnknown() {
+			d.Price = types.Float64Null()
+		}
+		if d.Currency.IsUnknown() {
+			d.Currency = types.StringNull()
+		}
+		return
+	}
+	d.Price = types.Float64Value(price)
+	d.Currency = types.StringValue(currency)
+}
+
 // converts kompute from Terraform model to Kowabunga API model
 func komputeResourceToModel(d *KomputeResourceModel) sdk.Kompute {
-	memSize := d.Memory.ValueInt64() * HelperGbToBytes
-	diskSize := d.Disk.ValueInt64() * HelperGbToBytes
-	extraDiskSize := d.ExtraDisk.ValueInt64() * HelperGbToBytes
+	memSize := d.Memory.ValueBytes()
+	diskSize := d.Disk.ValueBytes()
+	extraDiskSize := d.ExtraDisk.ValueBytes()
 
 	return sdk.Kompute{
 		Name:        d.Name.ValueString(),
@@ -158,13 +414,6 @@ func komputeModelToResource(r *sdk.Kompute, d *KomputeResourceModel) {
 		return
 	}
 
-	memSize := r.Memory / HelperGbToBytes
-	diskSize := r.Disk / HelperGbToBytes
-	var extraDiskSize int64 = 0
-	if r.DataDisk != nil {
-		extraDiskSize = *r.DataDisk / HelperGbToBytes
-	}
-
 	d.Name = types.StringValue(r.Name)
 	if r.Description != nil {
 		d.Desc = types.StringPointerValue(r.Description)
@@ -172,9 +421,10 @@ func komputeModelToResource(r *sdk.Kompute, d *KomputeResourceModel) {
 		d.Desc = types.StringValue("")
 	}
 	d.VCPUs = types.Int64Value(r.Vcpus)
-	d.Memory = types.Int64Value(memSize)
-	d.Disk = types.Int64Value(diskSize)
-	d.ExtraDisk = types.Int64Value(extraDiskSize)
+	d.Memory = NewSizeGBValueFromBytes(r.Memory)
+	// disks may have grown beyond configured size (e.g. from template)
+	d.Disk = sizeGBGrowOnlyValue(d.Disk, NewSizeGBValueFromBytes(r.Disk))
+	d.ExtraDisk = sizeGBGrowOnlyValue(d.ExtraDisk, NewSizeGBValueFromBytes(r.GetDataDisk()))
 	if r.Ip != nil {
 		d.IP = types.StringPointerValue(r.Ip)
 	} else {
@@ -182,6 +432,100 @@ func komputeModelToResource(r *sdk.Kompute, d *KomputeResourceModel) {
 	}
 }
 
+// records actual disk sizes (in bytes), checked by grow-only plan modifiers
+func komputeSetActualDisks(ctx context.Context, private privateState, disk, extraDisk int64) diag.Diagnostics {
+	diags := growOnlySetActual(ctx, private, KeyDisk, NewSizeGBValueFromBytes(disk))
+	diags.Append(growOnlySetActual(ctx, private, KeyExtraDisk, NewSizeGBValueFromBytes(extraDisk))...)
+	return diags
+}
+
+// deregisters kompute from all project's Konvey backends, returns whether it
+// was registered to any, connections being left to drain. Nothing is
+// deregistered if it would leave any endpoint without backend.
+func komputeDeregister(ctx context.Context, data *KowabungaProviderData, d *KomputeResourceModel) (bool, error) {
+	ip := d.IP.ValueString()
+	if ip == "" {
+		return false, nil
+	}
+
+	projectId, err := getProjectID(ctx, data, d.Project.ValueString())
+	if err != nil {
+		return false, err
+	}
+	project, _, err := data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+	if err != nil {
+		return false, err
+	}
+
+	konveys := []string{}
+	for _, regionId := range project.Regions {
+		ids, _, err := data.K.ProjectAPI.ListProjectRegionKonveys(ctx, projectId, regionId).Execute()
+		if err != nil {
+			return false, err
+		}
+		konveys = append(konveys, ids...)
+	}
+
+	// Konveys are all checked before any gets updated, so keep them locked
+	// until then, always in the same order not to deadlock with concurrent
+	// deregistrations
+	slices.Sort(konveys)
+	unlocks := []func(){}
+	defer func() {
+		for _, unlock := range unlocks {
+			unlock()
+		}
+	}()
+
+	updates := []*sdk.Konvey{}
+	for _, konveyId := range konveys {
+		unlocks = append(unlocks, data.Locks.lock(KonveyResourceName, konveyId))
+		konvey, _, err := data.K.KonveyAPI.ReadKonvey(ctx, konveyId).Execute()
+		if err != nil {
+			return false, err
+		}
+
+		updated := false
+		for i, ep := range konvey.Endpoints {
+			hosts := slices.DeleteFunc(slices.Clone(ep.Backends.Hosts), func(h string) bool {
+				return h == ip
+			})
+			if len(hosts) == len(ep.Backends.Hosts) {
+				continue
+			}
+			if len(hosts) == 0 {
+				return false, fmt.Errorf(KomputeErrLastBackend, konvey.GetName(), ep.Name)
+			}
+			konvey.Endpoints[i].Backends.Hosts = hosts
+			updated = true
+		}
+		if updated {
+			updates = append(updates, konvey)
+		}
+	}
+
+	for _, konvey := range updates {
+		tflog.Debug(ctx, "deregistering Kompute from Konvey "+konvey.GetId())
+		_, _, err = data.K.KonveyAPI.UpdateKonvey(ctx, konvey.GetId()).Konvey(*konvey).Execute()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return len(updates) > 0, nil
+}
+
+func (r *KomputeResource) powerActions(ctx context.Context, id string) powerActions {
+	return powerActions{
+		Start:    r.Data.K.KomputeAPI.StartKompute(ctx, id).Execute,
+		Stop:     r.Data.K.KomputeAPI.StopKompute(ctx, id).Execute,
+		Reboot:   r.Data.K.KomputeAPI.RebootKompute(ctx, id).Execute,
+		Shutdown: r.Data.K.KomputeAPI.ShutdownKompute(ctx, id).Execute,
+		State:    r.Data.K.KomputeAPI.ReadKomputeState(ctx, id).Execute,
+		Mutex:    r.Data.Mutex,
+	}
+}
+
 func (r *KomputeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *KomputeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -213,13 +557,27 @@ func (r *KomputeResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	// find parent pool (optional)
-	poolId, _ := getPoolID(ctx, r.Data, data.Pool.ValueString())
-
+	poolId, err := getPoolID(ctx, r.Data, data.Pool.ValueString())
+	if err = optionalReferenceError(r.Data, data.Pool.ValueString(), err); err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
 	// find parent template (optional)
-	templateId, _ := getTemplateID(ctx, r.Data, data.Template.ValueString(), poolId)
+	templateId, err := getTemplateID(ctx, r.Data, data.Template.ValueString(), poolId)
+	if err = optionalReferenceError(r.Data, data.Template.ValueString(), err); err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
 
-	// create a new Kompute
+	// ensure project has enough resources left before creating anything
 	m := komputeResourceToModel(data)
+	err = checkProjectQuotas(ctx, r.Data, projectId, komputeQuotaRequest(&m))
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+
+	// create a new Kompute
 	api := r.Data.K.ProjectAPI.CreateProjectZoneKompute(ctx, projectId, zoneId).Kompute(m).Public(data.Public.ValueBool())
 	if poolId != "" {
 		api = api.PoolId(poolId)
@@ -234,6 +592,21 @@ func (r *KomputeResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 	data.ID = types.StringPointerValue(kompute.Id)
 	komputeModelToResource(kompute, data) // read back resulting object
+	resp.Diagnostics.Append(komputeSetActualDisks(ctx, resp.Private, kompute.Disk, kompute.GetDataDisk())...)
+	r.estimatePrice(ctx, data, false)
+
+	// newly created Kompute is running
+	if data.State.ValueString() == PowerStateStopped {
+		if !resourceCreatePending(ctx, resp, &data) {
+			return
+		}
+		err = powerStateApply(ctx, PowerStateRunning, PowerStateStopped, r.powerActions(ctx, *kompute.Id))
+		if err != nil {
+			errorCreateGeneric(resp, err)
+			return
+		}
+		resourceCreateCompleted(ctx, resp)
+	}
 	tflog.Trace(ctx, "created Kompute resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -244,6 +617,7 @@ func (r *KomputeResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -261,7 +635,24 @@ func (r *KomputeResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// resolve parent references, unknown after import
+	if data.Project.IsNull() || data.Zone.IsNull() {
+		project, zone, err := getProjectZoneParents(ctx, r.Data, data.ID.ValueString(), func(projectId, zoneId string) ([]string, *http.Response, error) {
+			return r.Data.K.ProjectAPI.ListProjectZoneKomputes(ctx, projectId, zoneId).Execute()
+		})
+		if err != nil {
+			errorReadGeneric(resp, err)
+			return
+		}
+		data.Project = types.StringValue(project)
+		data.Zone = types.StringValue(zone)
+	}
+
 	komputeModelToResource(kompute, data)
+	resp.Diagnostics.Append(komputeSetActualDisks(ctx, resp.Private, kompute.Disk, kompute.GetDataDisk())...)
+	r.estimatePrice(ctx, data, true)
+
+	data.State = types.StringValue(powerStateRead(ctx, r.powerActions(ctx, data.ID.ValueString()), data.State.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -282,8 +673,37 @@ func (r *KomputeResource) Update(ctx context.Context, req resource.UpdateRequest
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
+	kompute, _, err := r.Data.K.KomputeAPI.ReadKompute(ctx, data.ID.ValueString()).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+
+	// never request disks smaller than actual ones
 	m := komputeResourceToModel(data)
-	_, _, err := r.Data.K.KomputeAPI.UpdateKompute(ctx, data.ID.ValueString()).Kompute(m).Execute()
+	m.Disk = max(m.Disk, kompute.Disk)
+	m.DataDisk = sdk.PtrInt64(max(m.GetDataDisk(), kompute.GetDataDisk()))
+	_, _, err = r.Data.K.KomputeAPI.UpdateKompute(ctx, data.ID.ValueString()).Kompute(m).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	resp.Diagnostics.Append(komputeSetActualDisks(ctx, resp.Private, m.Disk, m.GetDataDisk())...)
+	r.estimatePrice(ctx, data, false)
+
+	var current, trigger types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(KeyState), &current)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(KeyRebootTrigger), &trigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	actions := r.powerActions(ctx, data.ID.ValueString())
+	err = powerStateApply(ctx, current.ValueString(), data.State.ValueString(), actions)
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	err = powerRebootOnTrigger(trigger, data.Reboot, current.ValueString(), data.State.ValueString(), actions)
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
@@ -306,6 +726,26 @@ func (r *KomputeResource) Delete(ctx context.Context, req resource.DeleteRequest
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	if data.Drain.ValueInt64() > 0 {
+		r.Data.Mutex.Lock()
+		deregistered, err := komputeDeregister(ctx, r.Data, data)
+		r.Data.Mutex.Unlock()
+		if err != nil {
+			errorDeleteGeneric(resp, err)
+			return
+		}
+
+		// give connections some time to drain, without holding other resources back
+		if deregistered {
+			select {
+			case <-ctx.Done():
+				errorDeleteGeneric(resp, ctx.Err())
+				return
+			case <-time.After(time.Duration(data.Drain.ValueInt64()) * time.Second):
+			}
+		}
+	}
+
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKomputeDeregisterConcurrent(t *testing.T) {
+	var mutex sync.Mutex
+	konvey := sdk.Konvey{
+		Id:   sdk.PtrString("lb"),
+		Name: sdk.PtrString("lb"),
+		Endpoints: []sdk.KonveyEndpoint{{
+			Name:     "http",
+			Port:     80,
+			Protocol: "tcp",
+			Backends: sdk.KonveyBackends{Hosts: []string{"10.0.0.1", "10.0.0.2"}, Port: 80},
+		}},
+	}
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.URL.Path == "/api/v1/project/p1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "p1", "name": "p1", "teams": []string{}, "regions": []string{"r1"}})
+		case req.URL.Path == "/api/v1/project/p1/region/r1/konveys":
+			_ = json.NewEncoder(w).Encode([]string{"lb"})
+		case req.URL.Path == "/api/v1/konvey/lb" && req.Method == http.MethodGet:
+			mutex.Lock()
+			b, _ := json.Marshal(konvey)
+			mutex.Unlock()
+			// leave room for a concurrent read-modify-write
+			time.Sleep(20 * time.Millisecond)
+			_, _ = w.Write(b)
+		case req.URL.Path == "/api/v1/konvey/lb" && req.Method == http.MethodPut:
+			mutex.Lock()
+			_ = json.NewDecoder(req.Body).Decode(&konvey)
+			b, _ := json.Marshal(konvey)
+			mutex.Unlock()
+			_, _ = w.Write(b)
+		default:
+			http.NotFound(w, req)
+		}
+	})
+	data := &KowabungaProviderData{K: k, IDs: newIDCache(IDCacheDefaultTTL), Locks: newObjectLocks()}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := komputeDeregister(context.Background(), data, &KomputeResourceModel{
+				Project: types.StringValue("p1"),
+				IP:      types.StringValue(ip),
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("got %d failed deregistrations, want 1 (last backend)", failed)
+	}
+	if hosts := konvey.Endpoints[0].Backends.Hosts; len(hosts) != 1 {
+		t.Errorf("got backends %v, want exactly one left", hosts)
+	}
+}
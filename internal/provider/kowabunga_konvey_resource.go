@@ -8,21 +8,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"strings"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -33,10 +35,20 @@ const (
 
 	KonveyDefaultValueFailover = true
 	KonveyDefaultValueProtocol = "tcp"
+
+	KonveyErrBackends          = "Invalid endpoint backends"
+	KonveyErrBackendsExclusive = "either backend_ips, backend_vms or backend_sets/active_backend_set must be specified"
+	KonveyErrBackendSetUnknown = "active backend set does not exist"
+	KonveyErrBackendVM         = "Unknown endpoint backend virtual machine"
+	KonveyErrBackendVMNoIP     = "virtual machine has no private IP address"
+	KonveyErrImport            = "Unknown Konvey"
+	KonveyErrImportFormat      = "Konvey import identifier must either be an ID or a project/name pair"
 )
 
 var _ resource.Resource = &KonveyResource{}
 var _ resource.ResourceWithImportState = &KonveyResource{}
+var _ resource.ResourceWithValidateConfig = &KonveyResource{}
+var _ resource.ResourceWithModifyPlan = &KonveyResource{}
 
 func NewKonveyResource() resource.Resource {
 	return &KonveyResource{}
@@ -63,7 +75,10 @@ type KonveyEndpoint struct {
 	Protocol    types.String `tfsdk:"protocol"`
 	Port        types.Int64  `tfsdk:"port"`
 	BackendPort types.Int64  `tfsdk:"backend_port"`
-	BackendIPs  types.List   `tfsdk:"backend_ips"` // []string
+	BackendIPs  types.List   `tfsdk:"backend_ips"`  // []string
+	BackendVMs  types.List   `tfsdk:"backend_vms"`  // []string
+	BackendSets types.Map    `tfsdk:"backend_sets"` // map[string][]string
+	ActiveSet   types.String `tfsdk:"active_backend_set"`
 }
 
 func (r *KonveyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,7 +86,63 @@ func (r *KonveyResource) Metadata(ctx context.Context, req resource.MetadataRequ
 }
 
 func (r *KonveyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resourceImportState(ctx, req, resp)
+	// regular import by ID
+	if !strings.Contains(req.ID, "/") {
+		resourceImportState(ctx, req, resp)
+		return
+	}
+
+	// import by project/name composite identifier
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(KonveyErrImport, fmt.Sprintf("%s: %s", KonveyErrImportFormat, req.ID))
+		return
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	konveyId, region, err := konveyLookup(ctx, r.Data, parts[0], parts[1])
+	if err != nil {
+		resp.Diagnostics.AddError(KonveyErrImport, err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KeyID), konveyId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KeyProject), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KeyRegion), region)...)
+}
+
+// finds a project's Konvey ID and region name from its name
+func konveyLookup(ctx context.Context, data *KowabungaProviderData, project, name string) (string, string, error) {
+	projectId, err := getProjectID(ctx, data, project)
+	if err != nil {
+		return "", "", err
+	}
+	p, _, err := data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, regionId := range p.Regions {
+		konveys, _, err := data.K.ProjectAPI.ListProjectRegionKonveys(ctx, projectId, regionId).Execute()
+		if err != nil {
+			return "", "", err
+		}
+		for _, konveyId := range konveys {
+			konvey, _, err := data.K.KonveyAPI.ReadKonvey(ctx, konveyId).Execute()
+			if err != nil || konvey.GetName() != name {
+				continue
+			}
+			region, _, err := data.K.RegionAPI.ReadRegion(ctx, regionId).Execute()
+			if err != nil {
+				return "", "", err
+			}
+			return konveyId, region.Name, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%s: %s/%s", KonveyErrImport, project, name)
 }
 
 func (r *KonveyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -88,33 +159,30 @@ func (r *KonveyResource) SchemaEndpoints() schema.ListNestedAttribute {
 					MarkdownDescription: "Konvey endpoint name",
 					Required:            true,
 				},
-				KeyProtocol: schema.StringAttribute{
-					MarkdownDescription: "The endpoint's transport layer protocol to be exposed (defaults to 'tcp').",
+				KeyProtocol:    resourceNetworkProtocolAttribute("of the endpoint to be exposed", KonveyDefaultValueProtocol),
+				KeyPort:        resourceNetworkPortAttribute("The endpoint's port to be exposed."),
+				KeyBackendPort: resourceNetworkPortAttribute("The endpoint's backend service port."),
+				KeyBackendIPs: schema.ListAttribute{
+					MarkdownDescription: "The endpoint's list of load-balanced backend hosts. Computed from the active backend set if `backend_sets` is used instead, or from virtual machines private IP addresses if `backend_vms` is used instead.",
 					Optional:            true,
 					Computed:            true,
-					Default:             stringdefault.StaticString(KonveyDefaultValueProtocol),
-					Validators: []validator.String{
-						&stringNetworkProtocolValidator{},
-					},
+					ElementType:         types.StringType,
 				},
-				KeyPort: schema.Int64Attribute{
-					MarkdownDescription: "The endpoint's port to be exposed.",
-					Required:            true,
-					Validators: []validator.Int64{
-						&intNetworkPortValidator{},
-					},
+				KeyBackendVMs: schema.ListAttribute{
+					MarkdownDescription: "The endpoint's list of load-balanced backend Kompute or instance IDs, exclusive with `backend_ips` and `backend_sets`. Their private IP addresses are resolved at plan time, so that backend membership follows virtual machines recreation or re-addressing.",
+					Optional:            true,
+					ElementType:         types.StringType,
 				},
-				KeyBackendPort: schema.Int64Attribute{
-					MarkdownDescription: "The endpoint's backend service port.",
-					Required:            true,
-					Validators: []validator.Int64{
-						&intNetworkPortValidator{},
+				KeyBackendSets: schema.MapAttribute{
+					MarkdownDescription: "The endpoint's named sets of load-balanced backend hosts (e.g. `blue` and `green`), exclusive with `backend_ips`. Only the active one is exposed, switching from one to another is performed in a single update.",
+					Optional:            true,
+					ElementType: types.ListType{
+						ElemType: types.StringType,
 					},
 				},
-				KeyBackendIPs: schema.ListAttribute{
-					MarkdownDescription: "The endpoint's list of load-balanced backend hosts.",
-					Required:            true,
-					ElementType:         types.StringType,
+				KeyActiveBackendSet: schema.StringAttribute{
+					MarkdownDescription: "The endpoint's active backend set name, required if `backend_sets` is used.",
+					Optional:            true,
 				},
 			},
 		},
@@ -126,7 +194,7 @@ func (r *KonveyResource) SchemaEndpoints() schema.ListNestedAttribute {
 
 func (r *KonveyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Konvey resource. **Konvey** provides network load-balancer capabilities for a given project.",
+		MarkdownDescription: "Manages a Konvey resource. **Konvey** provides network load-balancer capabilities for a given project. Existing Konvey can be imported either by ID or by `project/name` composite identifier.",
 		Attributes: map[string]schema.Attribute{
 			KeyProject: schema.StringAttribute{
 				MarkdownDescription: "Associated project name or ID",
@@ -160,6 +228,200 @@ func (r *KonveyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
 }
 
+func (r *KonveyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data KonveyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Endpoints.IsNull() || data.Endpoints.IsUnknown() {
+		return
+	}
+
+	endpoints := []KonveyEndpoint{}
+	resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for idx, ep := range endpoints {
+		if ep.BackendIPs.IsUnknown() || ep.BackendVMs.IsUnknown() || ep.BackendSets.IsUnknown() || ep.ActiveSet.IsUnknown() {
+			continue
+		}
+
+		p := path.Root(KeyEndpoints).AtListIndex(idx)
+		useIPs := !ep.BackendIPs.IsNull()
+		useVMs := !ep.BackendVMs.IsNull()
+		useSets := !ep.BackendSets.IsNull() || !ep.ActiveSet.IsNull()
+		modes := 0
+		for _, used := range []bool{useIPs, useVMs, useSets} {
+			if used {
+				modes++
+			}
+		}
+		if modes != 1 || (useSets && (ep.BackendSets.IsNull() || ep.ActiveSet.IsNull())) {
+			resp.Diagnostics.AddAttributeError(p, KonveyErrBackends, fmt.Sprintf("%s: %s", ep.Name.ValueString(), KonveyErrBackendsExclusive))
+			continue
+		}
+
+		if useSets {
+			if _, ok := ep.BackendSets.Elements()[ep.ActiveSet.ValueString()]; !ok {
+				resp.Diagnostics.AddAttributeError(p.AtName(KeyActiveBackendSet), KonveyErrBackends, fmt.Sprintf("%s: %s: %s", ep.Name.ValueString(), KonveyErrBackendSetUnknown, ep.ActiveSet.ValueString()))
+			}
+		}
+	}
+}
+
+// resolves a Kompute or instance private IP address, from its first network
+// adapter for the latter, empty if not known yet (e.g. still being created)
+func konveyBackendVMAddress(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	kompute, httpResp, err := data.K.KomputeAPI.ReadKompute(ctx, id).Execute()
+	if err == nil {
+		return kompute.GetIp(), nil
+	}
+	if !errorIsNotFound(httpResp, err) {
+		return "", err
+	}
+
+	// not a Kompute, may be a raw instance then
+	instance, _, err := data.K.InstanceAPI.ReadInstance(ctx, id).Execute()
+	if err != nil {
+		return "", err
+	}
+	if len(instance.Adapters) == 0 {
+		return "", nil
+	}
+	adapter, _, err := data.K.AdapterAPI.ReadAdapter(ctx, instance.Adapters[0]).Execute()
+	if err != nil {
+		return "", err
+	}
+	if len(adapter.Addresses) == 0 {
+		return "", nil
+	}
+	return adapter.Addresses[0], nil
+}
+
+// returns the private IP addresses of the endpoint's backend virtual
+// machines, if any, unknown as long as one of them is yet to be created or
+// to get its address assigned
+func (r *KonveyResource) backendVMsAddresses(ctx context.Context, ep *KonveyEndpoint, diags *diag.Diagnostics, p path.Path) (types.List, bool) {
+	if ep.BackendVMs.IsNull() {
+		return types.ListNull(types.StringType), false
+	}
+	if ep.BackendVMs.IsUnknown() {
+		return types.ListUnknown(types.StringType), true
+	}
+
+	hosts := []string{}
+	for _, item := range ep.BackendVMs.Elements() {
+		id, ok := item.(types.String)
+		if !ok || id.IsUnknown() {
+			return types.ListUnknown(types.StringType), true
+		}
+		ip, err := konveyBackendVMAddress(ctx, r.Data, id.ValueString())
+		if err != nil {
+			diags.AddAttributeError(p.AtName(KeyBackendVMs), KonveyErrBackendVM, err.Error())
+			return types.ListUnknown(types.StringType), false
+		}
+		if ip == "" {
+			return types.ListUnknown(types.StringType), true
+		}
+		hosts = append(hosts, ip)
+	}
+
+	backends, d := types.ListValueFrom(ctx, types.StringType, hosts)
+	diags.Append(d...)
+	return backends, true
+}
+
+// resolves backend virtual machines private IP addresses left unknown at
+// plan time, which must be known by apply time
+func (r *KonveyResource) resolveBackendVMs(ctx context.Context, d *KonveyResourceModel, diags *diag.Diagnostics) {
+	if d.Endpoints.IsNull() || d.Endpoints.IsUnknown() {
+		return
+	}
+	endpoints := []KonveyEndpoint{}
+	diags.Append(d.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	for idx := range endpoints {
+		ep := &endpoints[idx]
+		if ep.BackendVMs.IsNull() || !ep.BackendIPs.IsUnknown() {
+			continue
+		}
+		p := path.Root(KeyEndpoints).AtListIndex(idx)
+		backends, _ := r.backendVMsAddresses(ctx, ep, diags, p)
+		if diags.HasError() {
+			return
+		}
+		if backends.IsUnknown() {
+			diags.AddAttributeError(p.AtName(KeyBackendVMs), KonveyErrBackendVM, KonveyErrBackendVMNoIP)
+			return
+		}
+		ep.BackendIPs = backends
+	}
+
+	list, dg := types.ListValueFrom(ctx, d.Endpoints.ElementType(ctx), endpoints)
+	diags.Append(dg...)
+	d.Endpoints = list
+}
+
+// exposes the active backend set hosts, or backend virtual machines private
+// IP addresses, as planned backend IPs
+func (r *KonveyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data KonveyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Endpoints.IsNull() || data.Endpoints.IsUnknown() {
+		return
+	}
+
+	endpoints := []KonveyEndpoint{}
+	resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for idx, ep := range endpoints {
+		p := path.Root(KeyEndpoints).AtListIndex(idx)
+		if !ep.BackendVMs.IsNull() {
+			// provider is not configured yet on validation-only plans
+			if r.Data == nil {
+				continue
+			}
+			r.Data.Mutex.Lock()
+			backends, ok := r.backendVMsAddresses(ctx, &ep, &resp.Diagnostics, p)
+			r.Data.Mutex.Unlock()
+			if !ok {
+				continue
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p.AtName(KeyBackendIPs), backends)...)
+			continue
+		}
+
+		hosts, ok := konveyEndpointActiveBackends(&ctx, &ep)
+		if !ok {
+			continue
+		}
+		backends, diags := types.ListValueFrom(ctx, types.StringType, hosts)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p.AtName(KeyBackendIPs), backends)...)
+	}
+}
+
 //////////////////////////////////////////////////////////////
 // converts konvey from Terraform model to Kowabunga API model //
 //////////////////////////////////////////////////////////////
@@ -188,11 +450,14 @@ func konveyEndpointsModel(ctx *context.Context, d *KonveyResourceModel) []sdk.Ko
 		}
 
 		// backends
-		hosts := make([]string, 0, len(ep.BackendIPs.Elements()))
-		diags = ep.BackendIPs.ElementsAs(*ctx, &hosts, false)
-		if diags.HasError() {
-			for _, err := range diags.Errors() {
-				tflog.Debug(*ctx, err.Detail())
+		hosts, ok := konveyEndpointActiveBackends(ctx, &ep)
+		if !ok {
+			hosts = make([]string, 0, len(ep.BackendIPs.Elements()))
+			diags = ep.BackendIPs.ElementsAs(*ctx, &hosts, false)
+			if diags.HasError() {
+				for _, err := range diags.Errors() {
+					tflog.Debug(*ctx, err.Detail())
+				}
 			}
 		}
 
@@ -212,6 +477,25 @@ func konveyEndpointsModel(ctx *context.Context, d *KonveyResourceModel) []sdk.Ko
 	return epModel
 }
 
+// returns the hosts of the endpoint's active backend set, if any
+func konveyEndpointActiveBackends(ctx *context.Context, ep *KonveyEndpoint) ([]string, bool) {
+	if ep.ActiveSet.IsNull() || ep.ActiveSet.IsUnknown() || ep.BackendSets.IsNull() || ep.BackendSets.IsUnknown() {
+		return nil, false
+	}
+
+	sets := map[string][]string{}
+	diags := ep.BackendSets.ElementsAs(*ctx, &sets, false)
+	if diags.HasError() {
+		for _, err := range diags.Errors() {
+			tflog.Debug(*ctx, err.Detail())
+		}
+		return nil, false
+	}
+
+	hosts, ok := sets[ep.ActiveSet.ValueString()]
+	return hosts, ok
+}
+
 func konveyResourceToModel(ctx *context.Context, d *KonveyResourceModel) sdk.Konvey {
 	return sdk.Konvey{
 		Name:        d.Name.ValueStringPointer(),
@@ -235,6 +519,30 @@ func konveyModelToEndpoints(ctx *context.Context, r *sdk.Konvey, d *KonveyResour
 		KeyBackendIPs: types.ListType{
 			ElemType: types.StringType,
 		},
+		KeyBackendVMs: types.ListType{
+			ElemType: types.StringType,
+		},
+		KeyBackendSets: types.MapType{
+			ElemType: types.ListType{
+				ElemType: types.StringType,
+			},
+		},
+		KeyActiveBackendSet: types.StringType,
+	}
+
+	// backend sets and virtual machines are not known from API, keep track of prior ones
+	priorEndpoints := map[string]KonveyEndpoint{}
+	if !d.Endpoints.IsNull() && !d.Endpoints.IsUnknown() {
+		prior := []KonveyEndpoint{}
+		diags := d.Endpoints.ElementsAs(*ctx, &prior, false)
+		if diags.HasError() {
+			for _, err := range diags.Errors() {
+				tflog.Debug(*ctx, err.Detail())
+			}
+		}
+		for _, ep := range prior {
+			priorEndpoints[ep.Name.ValueString()] = ep
+		}
 	}
 
 	// empty endpoints ?
@@ -257,6 +565,18 @@ func konveyModelToEndpoints(ctx *context.Context, r *sdk.Konvey, d *KonveyResour
 		}
 		r[KeyBackendIPs], _ = types.ListValue(types.StringType, hosts)
 
+		r[KeyBackendVMs] = types.ListNull(types.StringType)
+		r[KeyBackendSets] = types.MapNull(endpointsType[KeyBackendSets].(types.MapType).ElemType)
+		r[KeyActiveBackendSet] = types.StringNull()
+		prior, ok := priorEndpoints[ep.Name]
+		if ok && !prior.BackendVMs.IsNull() {
+			r[KeyBackendVMs] = prior.BackendVMs
+		}
+		if ok && !prior.BackendSets.IsNull() {
+			r[KeyBackendSets] = prior.BackendSets
+			r[KeyActiveBackendSet] = prior.ActiveSet
+		}
+
 		object, _ := types.ObjectValue(endpointsType, r)
 		endpoints = append(endpoints, object)
 	}
@@ -327,6 +647,10 @@ func (r *KonveyResource) Create(ctx context.Context, req resource.CreateRequest,
 		errorCreateGeneric(resp, err)
 		return
 	}
+	r.resolveBackendVMs(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	m := konveyResourceToModel(&ctx, data)
 
 	// create a new Konvey
@@ -387,6 +711,14 @@ func (r *KonveyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
+	// backends may be concurrently deregistered by Kompute deletion
+	unlock := r.Data.Locks.lock(KonveyResourceName, data.ID.ValueString())
+	defer unlock()
+
+	r.resolveBackendVMs(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	m := konveyResourceToModel(&ctx, data)
 	_, _, err := r.Data.K.KonveyAPI.UpdateKonvey(ctx, data.ID.ValueString()).Konvey(m).Execute()
 	if err != nil {
@@ -415,6 +747,9 @@ func (r *KonveyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
+	unlock := r.Data.Locks.lock(KonveyResourceName, data.ID.ValueString())
+	defer unlock()
+
 	_, err := r.Data.K.KonveyAPI.DeleteKonvey(ctx, data.ID.ValueString()).Execute()
 	if err != nil {
 		errorDeleteGeneric(resp, err)
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestKonveyBackendVMAddress(t *testing.T) {
+	responses := map[string]any{
+		"/api/v1/kompute/web":     map[string]any{"id": "web", "name": "web", "memory": 1, "vcpus": 1, "disk": 10, "ip": "10.0.0.10"},
+		"/api/v1/kompute/new":     map[string]any{"id": "new", "name": "new", "memory": 1, "vcpus": 1, "disk": 10},
+		"/api/v1/instance/db":     map[string]any{"id": "db", "name": "db", "memory": 1, "vcpus": 1, "adapters": []string{"eth0"}},
+		"/api/v1/adapter/eth0":    map[string]any{"id": "eth0", "name": "eth0", "addresses": []string{"10.0.0.20"}},
+		"/api/v1/instance/empty":  map[string]any{"id": "empty", "name": "empty", "memory": 1, "vcpus": 1},
+		"/api/v1/instance/locked": map[string]any{"id": "locked", "name": "locked", "memory": 1, "vcpus": 1, "adapters": []string{"eth0"}},
+	}
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v1/kompute/locked" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		r, ok := responses[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r)
+	})
+	data := &KowabungaProviderData{K: k}
+
+	tests := []struct {
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{id: "web", want: "10.0.0.10"},
+		{id: "new"},                    // Kompute without IP yet
+		{id: "db", want: "10.0.0.20"},  // instance fallback on not-found Kompute
+		{id: "empty"},                  // instance without adapter yet
+		{id: "locked", wantErr: true},  // no fallback on other Kompute errors
+		{id: "missing", wantErr: true}, // neither Kompute nor instance
+	}
+	for _, tt := range tests {
+		got, err := konveyBackendVMAddress(context.Background(), data, tt.id)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error: %t", tt.id, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
@@ -9,18 +9,23 @@ package provider
 import (
 	"context"
 	"maps"
+	"slices"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -32,8 +37,28 @@ const (
 	KyloDefaultValueAccessType = "RW"
 )
 
+var kyloSupportedAccessTypes = []string{
+	"RW",
+	"RO",
+}
+
+var kyloSupportedProtocols = []int64{
+	3,
+	4,
+}
+
+// protocols turned from a list into a set, so that ordering is irrelevant
+var kyloChangedAttributes = []deprecatedAttribute{
+	{
+		Path:   path.Root(KeyProtocols),
+		Since:  "0.57.0",
+		Change: "`" + KeyProtocols + "` turned from a list into a set, its elements can no longer be referenced by index",
+	},
+}
+
 var _ resource.Resource = &KyloResource{}
 var _ resource.ResourceWithImportState = &KyloResource{}
+var _ resource.ResourceWithModifyPlan = &KyloResource{}
 
 func NewKyloResource() resource.Resource {
 	return &KyloResource{}
@@ -52,7 +77,7 @@ type KyloResourceModel struct {
 	Region    types.String   `tfsdk:"region"`
 	Nfs       types.String   `tfsdk:"nfs"`
 	Access    types.String   `tfsdk:"access_type"`
-	Protocols types.List     `tfsdk:"protocols"`
+	Protocols types.Set      `tfsdk:"protocols"`
 	// read-only
 	Endpoint types.String `tfsdk:"endpoint"`
 }
@@ -71,11 +96,11 @@ func (r *KyloResource) Configure(ctx context.Context, req resource.ConfigureRequ
 }
 
 func (r *KyloResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	prot := []attr.Value{
-		types.Int64Value(3),
-		types.Int64Value(4),
+	prot := []attr.Value{}
+	for _, p := range kyloSupportedProtocols {
+		prot = append(prot, types.Int64Value(p))
 	}
-	protocols, _ := types.ListValue(types.Int64Type, prot)
+	protocols, _ := types.SetValue(types.Int64Type, prot)
 
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Kylo distributed network storage resource. **Kylo** provides an elastic NFS-compatible endpoint.",
@@ -99,13 +124,20 @@ func (r *KyloResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(KyloDefaultValueAccessType),
+				Validators: []validator.String{
+					stringvalidator.OneOf(kyloSupportedAccessTypes...),
+				},
 			},
-			KeyProtocols: schema.ListAttribute{
-				MarkdownDescription: "Kylo's requested NFS protocols versions (defaults to NFSv3 and NFSv4))",
+			KeyProtocols: schema.SetAttribute{
+				MarkdownDescription: "Kylo's requested NFS protocols versions. Allowed values: 3 or 4. Defaults to NFSv3 and NFSv4. " + kyloChangedAttributes[0].Message(),
 				ElementType:         types.Int64Type,
 				Optional:            true,
 				Computed:            true,
-				Default:             listdefault.StaticValue(protocols),
+				Default:             setdefault.StaticValue(protocols),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueInt64sAre(int64validator.OneOf(kyloSupportedProtocols...)),
+				},
 			},
 			KeyEndpoint: schema.StringAttribute{
 				MarkdownDescription: "NFS Endoint (read-only)",
@@ -120,6 +152,14 @@ func (r *KyloResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 }
 
 // converts kylo from Terraform model to Kowabunga API model
+// reports changed attributes usage
+func (r *KyloResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.Data == nil {
+		return
+	}
+	deprecationWarnings(ctx, r.Data.Deprecations, KyloResourceName, req.Config, kyloChangedAttributes, &resp.Diagnostics)
+}
+
 func kyloResourceToModel(d *KyloResourceModel) sdk.Kylo {
 	protocols64 := []int64{}
 	d.Protocols.ElementsAs(context.TODO(), &protocols64, false)
@@ -154,11 +194,15 @@ func kyloModelToResource(r *sdk.Kylo, d *KyloResourceModel) {
 	} else {
 		d.Access = types.StringValue(KyloDefaultValueAccessType)
 	}
-	protocols := []attr.Value{}
-	for _, p := range r.Protocols {
-		protocols = append(protocols, types.Int64Value(int64(p)))
+	// protocols order is irrelevant, API reported duplicates are dropped as a
+	// set can't hold them, keep requested ones if unreported
+	if len(r.Protocols) > 0 {
+		protocols := []attr.Value{}
+		for _, p := range slices.Compact(slices.Sorted(slices.Values(r.Protocols))) {
+			protocols = append(protocols, types.Int64Value(int64(p)))
+		}
+		d.Protocols, _ = types.SetValue(types.Int64Type, protocols)
 	}
-	d.Protocols, _ = types.ListValue(types.Int64Type, protocols)
 	if r.Endpoint != nil {
 		d.Endpoint = types.StringPointerValue(r.Endpoint)
 	} else {
@@ -197,7 +241,11 @@ func (r *KyloResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 	// find parent NFS storage (optional)
-	nfsId, _ := getNfsID(ctx, r.Data, data.Nfs.ValueString())
+	nfsId, err := getNfsID(ctx, r.Data, data.Nfs.ValueString())
+	if err = optionalReferenceError(r.Data, data.Nfs.ValueString(), err); err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
 
 	// create a new Kylo
 	m := kyloResourceToModel(data)
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+)
+
+// API reported protocols end up as a set, whatever their order or duplicates
+func TestKyloModelToResourceProtocols(t *testing.T) {
+	var d KyloResourceModel
+	kyloModelToResource(&sdk.Kylo{Name: "kylo", Protocols: []int32{4, 3, 4}}, &d)
+
+	protocols := []int64{}
+	diags := d.Protocols.ElementsAs(context.Background(), &protocols, false)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	slices.Sort(protocols)
+	if want := []int64{3, 4}; !slices.Equal(protocols, want) {
+		t.Errorf("got %v, want %v", protocols, want)
+	}
+}
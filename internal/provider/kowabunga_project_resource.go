@@ -8,16 +8,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"slices"
 	"sort"
+	"strings"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -25,6 +32,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -40,10 +49,19 @@ const (
 	ProjectDefaultValueMaxMemory    = 0
 	ProjectDefaultValueMaxStorage   = 0
 	ProjectDefaultValueMaxVCPUs     = 0
+
+	ProjectDefaultValuePreventRegionRemoval = false
+
+	ProjectErrRegionRemoval  = "Project region removal prevented"
+	ProjectWarnRegionRemoval = "Project region removal"
+	ProjectWarnTeamRemoval   = "Project team removal"
+
+	ProjectPrivateKeyRootPasswordWO = "root_password_wo"
 )
 
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectResource{}
 
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
@@ -61,18 +79,23 @@ type ProjectResourceModel struct {
 	Domain         types.String   `tfsdk:"domain"`
 	SubnetSize     types.Int64    `tfsdk:"subnet_size"`
 	RootPassword   types.String   `tfsdk:"root_password"`
+	RootPasswordWO types.String   `tfsdk:"root_password_wo"`
+	RootPasswordWV types.Int64    `tfsdk:"root_password_wo_version"`
 	User           types.String   `tfsdk:"bootstrap_user"`
 	Pubkey         types.String   `tfsdk:"bootstrap_pubkey"`
 	Tags           types.List     `tfsdk:"tags"`
+	TagsAll        types.List     `tfsdk:"tags_all"`
 	Metadatas      types.Map      `tfsdk:"metadata"`
+	MetadatasAll   types.Map      `tfsdk:"metadata_all"`
 	MaxInstances   types.Int64    `tfsdk:"max_instances"`
-	MaxMemory      types.Int64    `tfsdk:"max_memory"`
-	MaxStorage     types.Int64    `tfsdk:"max_storage"`
+	MaxMemory      SizeGBValue    `tfsdk:"max_memory"`
+	MaxStorage     SizeGBValue    `tfsdk:"max_storage"`
 	MaxVCPUs       types.Int64    `tfsdk:"max_vcpus"`
 	PrivateSubnets types.Map      `tfsdk:"private_subnets"`
 	Teams          types.List     `tfsdk:"teams"`
 	Regions        types.List     `tfsdk:"regions"`
 	VRIDs          types.List     `tfsdk:"vrids"`
+	PreventRemoval types.Bool     `tfsdk:"prevent_region_removal"`
 }
 
 type ProjectQuotaModel struct {
@@ -111,11 +134,28 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			KeyRootPassword: schema.StringAttribute{
-				MarkdownDescription: "The project default root password, set at cloud-init instance bootstrap phase. Will be randomly auto-generated at each instance creation if unspecified.",
+				MarkdownDescription: fmt.Sprintf("The project default root password, set at cloud-init instance bootstrap phase. Will be randomly auto-generated at each instance creation, and never returned, if unspecified. Stored in state, use `%s` to prevent it.", KeyRootPasswordWO),
 				Optional:            true,
 				Computed:            true,
+				Sensitive:           true,
 				Default:             stringdefault.StaticString(ProjectDefaultValueRootPassword),
 			},
+			KeyRootPasswordWO: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Write-only project default root password, never persisted in state (requires Terraform 1.11+). Conflicts with `%s`. Bump `%s` to apply a new password.", KeyRootPassword, KeyRootPasswordWOVersion),
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot(KeyRootPassword)),
+				},
+			},
+			KeyRootPasswordWOVersion: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Version of the write-only `%s`, to be changed to trigger root password update", KeyRootPasswordWO),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot(KeyRootPasswordWO)),
+				},
+			},
 			KeyBootstrapUser: schema.StringAttribute{
 				MarkdownDescription: "The project default service user name, created at cloud-init instance bootstrap phase. Will use Kowabunga's default configuration one if unspecified.",
 				Optional:            true,
@@ -133,15 +173,25 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			KeyTags: schema.ListAttribute{
-				MarkdownDescription: "List of tags associated with the project",
+				MarkdownDescription: fmt.Sprintf("List of tags associated with the project. Provider's `%s` are added to them.", KeyDefaultTags),
 				ElementType:         types.StringType,
 				Required:            true,
 			},
+			KeyTagsAll: schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("List of all tags associated with the project, including provider's `%s` (read-only)", KeyDefaultTags),
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 			KeyMetadata: schema.MapAttribute{
-				MarkdownDescription: "List of metadatas key/value associated with the project",
+				MarkdownDescription: fmt.Sprintf("List of metadatas key/value associated with the project. Provider's `%s` are added to them, unless overridden.", KeyDefaultMetadata),
 				ElementType:         types.StringType,
 				Required:            true,
 			},
+			KeyMetadataAll: schema.MapAttribute{
+				MarkdownDescription: fmt.Sprintf("List of all metadatas key/value associated with the project, including provider's `%s` (read-only)", KeyDefaultMetadata),
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 			KeyMaxInstances: schema.Int64Attribute{
 				MarkdownDescription: "Project maximum deployable instances. Defaults to 0 (unlimited).",
 				Computed:            true,
@@ -152,12 +202,14 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Project maximum usable memory (expressed in GB). Defaults to 0 (unlimited).",
 				Computed:            true,
 				Optional:            true,
+				CustomType:          SizeGBType{},
 				Default:             int64default.StaticInt64(ProjectDefaultValueMaxMemory),
 			},
 			KeyMaxStorage: schema.Int64Attribute{
 				MarkdownDescription: "Project maximum usable storage (expressed in GB). Defaults to 0 (unlimited).",
 				Computed:            true,
 				Optional:            true,
+				CustomType:          SizeGBType{},
 				Default:             int64default.StaticInt64(ProjectDefaultValueMaxStorage),
 			},
 			KeyMaxVCPUs: schema.Int64Attribute{
@@ -180,10 +232,16 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:            true,
 			},
 			KeyRegions: schema.ListAttribute{
-				MarkdownDescription: "The list of regions the project is managing resources from (subnets will be pre-allocated in all referenced regions)",
+				MarkdownDescription: "The list of regions the project is managing resources from (subnets will be pre-allocated in all referenced regions). Removing a region releases the project's subnets there.",
 				ElementType:         types.StringType,
 				Required:            true,
 			},
+			KeyPreventRegionRemoval: schema.BoolAttribute{
+				MarkdownDescription: "Whether to reject any plan removing a region from the project, as it would release the project's subnets there (default: **false**)",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(ProjectDefaultValuePreventRegionRemoval),
+			},
 			KeyVRIDs: schema.ListAttribute{
 				Computed:            true,
 				MarkdownDescription: "List of VRRP IDs used by -as-a-service resources within the project virtual network (read-only). Should your application use VRRP for service redundancy, you should use different IDs to prevent issues.",
@@ -198,12 +256,14 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 }
 
 // converts project from Terraform model to Kowabunga API model
-func projectResourceToModel(d *ProjectResourceModel) sdk.Project {
+func projectResourceToModel(p *KowabungaProviderData, d *ProjectResourceModel) sdk.Project {
 	tags := []string{}
 	d.Tags.ElementsAs(context.TODO(), &tags, false)
+	tags = p.withDefaultTags(tags)
 
 	metas := map[string]string{}
 	d.Metadatas.ElementsAs(context.TODO(), &metas, false)
+	metas = p.withDefaultMetadata(metas)
 	metadatas := []sdk.Metadata{}
 	for k, v := range metas {
 		m := sdk.Metadata{
@@ -214,8 +274,8 @@ func projectResourceToModel(d *ProjectResourceModel) sdk.Project {
 	}
 
 	instances := int32(d.MaxInstances.ValueInt64())
-	memory := int64(d.MaxMemory.ValueInt64()) * HelperGbToBytes
-	storage := int64(d.MaxStorage.ValueInt64()) * HelperGbToBytes
+	memory := d.MaxMemory.ValueBytes()
+	storage := d.MaxStorage.ValueBytes()
 	vcpus := int32(d.MaxVCPUs.ValueInt64())
 	quotas := &sdk.ProjectResources{
 		Instances: &instances,
@@ -248,7 +308,7 @@ func projectResourceToModel(d *ProjectResourceModel) sdk.Project {
 }
 
 // converts project from Kowabunga API model to Terraform model
-func projectModelToResource(r *sdk.Project, d *ProjectResourceModel) {
+func projectModelToResource(p *KowabungaProviderData, r *sdk.Project, d *ProjectResourceModel) {
 	if r == nil {
 		return
 	}
@@ -280,17 +340,29 @@ func projectModelToResource(r *sdk.Project, d *ProjectResourceModel) {
 		d.Pubkey = types.StringValue("")
 	}
 
+	// provider's default tags and metadatas are only reported in *_all
+	// attributes, unless explicitly configured
+	configuredTags := []string{}
+	d.Tags.ElementsAs(context.TODO(), &configuredTags, false)
 	tags := []attr.Value{}
-	for _, t := range r.Tags {
+	for _, t := range p.withoutDefaultTags(r.Tags, configuredTags) {
 		tags = append(tags, types.StringValue(t))
 	}
 	d.Tags, _ = types.ListValue(types.StringType, tags)
+	d.TagsAll = projectTagsAll(r.Tags)
 
-	metadatas := map[string]attr.Value{}
+	configuredMetas := map[string]string{}
+	d.Metadatas.ElementsAs(context.TODO(), &configuredMetas, false)
+	metas := map[string]string{}
 	for _, m := range r.Metadatas {
-		metadatas[m.Key] = types.StringValue(m.Value)
+		metas[m.Key] = m.Value
+	}
+	metadatas := map[string]attr.Value{}
+	for k, v := range p.withoutDefaultMetadata(metas, configuredMetas) {
+		metadatas[k] = types.StringValue(v)
 	}
 	d.Metadatas = basetypes.NewMapValueMust(types.StringType, metadatas)
+	d.MetadatasAll = projectMetadatasAll(metas)
 
 	if r.Quotas.Instances != nil {
 		d.MaxInstances = types.Int64Value(int64(*r.Quotas.Instances))
@@ -298,14 +370,14 @@ func projectModelToResource(r *sdk.Project, d *ProjectResourceModel) {
 		d.MaxInstances = types.Int64Value(ProjectDefaultValueMaxInstances)
 	}
 	if r.Quotas.Memory != nil {
-		d.MaxMemory = types.Int64Value(int64(*r.Quotas.Memory) / HelperGbToBytes)
+		d.MaxMemory = NewSizeGBValueFromBytes(*r.Quotas.Memory)
 	} else {
-		d.MaxMemory = types.Int64Value(ProjectDefaultValueMaxMemory)
+		d.MaxMemory = NewSizeGBValue(ProjectDefaultValueMaxMemory)
 	}
 	if r.Quotas.Storage != nil {
-		d.MaxStorage = types.Int64Value(int64(*r.Quotas.Storage) / HelperGbToBytes)
+		d.MaxStorage = NewSizeGBValueFromBytes(*r.Quotas.Storage)
 	} else {
-		d.MaxStorage = types.Int64Value(ProjectDefaultValueMaxStorage)
+		d.MaxStorage = NewSizeGBValue(ProjectDefaultValueMaxStorage)
 	}
 	if r.Quotas.Vcpus != nil {
 		d.MaxVCPUs = types.Int64Value(int64(*r.Quotas.Vcpus))
@@ -347,6 +419,166 @@ func projectModelToResource(r *sdk.Project, d *ProjectResourceModel) {
 		vrids = append(vrids, types.Int64Value(int64(vrid)))
 	}
 	d.VRIDs, _ = types.ListValue(types.Int64Type, vrids)
+
+	// provider-side only setting, unknown from API (e.g. on import)
+	if d.PreventRemoval.IsNull() {
+		d.PreventRemoval = types.BoolValue(ProjectDefaultValuePreventRegionRemoval)
+	}
+}
+
+// returns all project tags, sorted so that API ordering never shows up as a diff
+func projectTagsAll(tags []string) types.List {
+	all := []attr.Value{}
+	for _, t := range slices.Sorted(slices.Values(tags)) {
+		all = append(all, types.StringValue(t))
+	}
+	return types.ListValueMust(types.StringType, all)
+}
+
+// returns all project metadatas
+func projectMetadatasAll(metas map[string]string) types.Map {
+	all := map[string]attr.Value{}
+	for k, v := range metas {
+		all[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, all)
+}
+
+// computes planned tags and metadatas, including provider's default ones, so
+// that any change of these shows up in plan
+func (r *ProjectResource) planAll(ctx context.Context, plan *ProjectResourceModel, resp *resource.ModifyPlanResponse) {
+	tagsAll := types.ListUnknown(types.StringType)
+	if !plan.Tags.IsUnknown() && !slices.ContainsFunc(plan.Tags.Elements(), attr.Value.IsUnknown) {
+		tags := []string{}
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+		tagsAll = projectTagsAll(r.Data.withDefaultTags(tags))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(KeyTagsAll), tagsAll)...)
+
+	metadatasAll := types.MapUnknown(types.StringType)
+	if !plan.Metadatas.IsUnknown() && !slices.ContainsFunc(slices.Collect(maps.Values(plan.Metadatas.Elements())), attr.Value.IsUnknown) {
+		metas := map[string]string{}
+		resp.Diagnostics.Append(plan.Metadatas.ElementsAs(ctx, &metas, true)...)
+		metadatasAll = projectMetadatasAll(r.Data.withDefaultMetadata(metas))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(KeyMetadataAll), metadatasAll)...)
+}
+
+// returns the items added to and removed from a list
+func projectDelta(prior, planned []string) ([]string, []string) {
+	added := []string{}
+	for _, item := range planned {
+		if !slices.Contains(prior, item) {
+			added = append(added, item)
+		}
+	}
+	removed := []string{}
+	for _, item := range prior {
+		if !slices.Contains(planned, item) {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
+
+// returns the items removed from a planned list attribute, if known. Items
+// are compared by resolved ID when provider is configured, so that switching a
+// reference from name to ID (or the other way round) is not a removal.
+func projectPlanRemovals(ctx context.Context, data *KowabungaProviderData, prior, planned types.List, resolve func(context.Context, *KowabungaProviderData, string) (string, error)) []string {
+	if prior.IsNull() || prior.IsUnknown() || planned.IsUnknown() {
+		return nil
+	}
+	before := []string{}
+	prior.ElementsAs(ctx, &before, false)
+	after := []string{}
+	planned.ElementsAs(ctx, &after, false)
+	_, removed := projectDelta(before, after)
+	if data == nil || len(removed) == 0 {
+		return removed
+	}
+
+	data.Mutex.Lock()
+	defer data.Mutex.Unlock()
+
+	// unresolvable references are compared as is
+	id := func(ref string) string {
+		resolved, err := resolve(ctx, data, ref)
+		if err != nil {
+			return ref
+		}
+		return resolved
+	}
+	kept := []string{}
+	for _, ref := range after {
+		kept = append(kept, id(ref))
+	}
+	removed = slices.DeleteFunc(removed, func(ref string) bool {
+		return slices.Contains(kept, id(ref))
+	})
+	return removed
+}
+
+// computes all planned tags and metadatas, warns about (or prevents) regions
+// and teams removal, as the project's subnets in removed regions are released
+// server-side
+func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ProjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.Data != nil {
+		r.planAll(ctx, &plan, resp)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state ProjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	regions := projectPlanRemovals(ctx, r.Data, state.Regions, plan.Regions, getRegionID)
+	if len(regions) > 0 {
+		msg := fmt.Sprintf("region(s) %s would be removed from project %s, releasing all of its subnets there", strings.Join(regions, ", "), state.Name.ValueString())
+		if plan.PreventRemoval.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root(KeyRegions), ProjectErrRegionRemoval, fmt.Sprintf("%s. Unset %s to proceed.", msg, KeyPreventRegionRemoval))
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root(KeyRegions), ProjectWarnRegionRemoval, msg)
+		}
+	}
+
+	teams := projectPlanRemovals(ctx, r.Data, state.Teams, plan.Teams, getTeamID)
+	if len(teams) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root(KeyTeams), ProjectWarnTeamRemoval, fmt.Sprintf("team(s) %s would no longer be allowed to administrate project %s", strings.Join(teams, ", "), state.Name.ValueString()))
+	}
+}
+
+// write-only attributes are never part of plan or state, root password has to
+// be retrieved from configuration when provided as such. Returns whether it is.
+func projectWriteOnlyRootPassword(ctx context.Context, config tfsdk.Config, m *sdk.Project, diags *diag.Diagnostics) bool {
+	var password types.String
+	diags.Append(config.GetAttribute(ctx, path.Root(KeyRootPasswordWO), &password)...)
+	if diags.HasError() || password.IsNull() || password.IsUnknown() {
+		return false
+	}
+	m.RootPassword = password.ValueStringPointer()
+	return true
+}
+
+// root password is only tracked in state if not provided through write-only
+// attribute, as remembered in private state
+func projectRootPasswordPrivateState(writeOnly bool) []byte {
+	if writeOnly {
+		return []byte(`true`)
+	}
+	return nil
 }
 
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -368,15 +600,24 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	defer r.Data.Mutex.Unlock()
 
 	// create a new project
-	m := projectResourceToModel(data)
+	m := projectResourceToModel(r.Data, data)
+	writeOnly := projectWriteOnlyRootPassword(ctx, req.Config, &m, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	project, _, err := r.Data.K.ProjectAPI.CreateProject(ctx).Project(m).SubnetSize(int32(data.SubnetSize.ValueInt64())).Execute()
 	if err != nil {
 		errorCreateGeneric(resp, err)
 		return
 	}
 	data.ID = types.StringPointerValue(project.Id)
-	projectModelToResource(project, data) // read back resulting object
+	projectModelToResource(r.Data, project, data) // read back resulting object
+	if writeOnly {
+		data.RootPassword = types.StringValue(ProjectDefaultValueRootPassword)
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ProjectPrivateKeyRootPasswordWO, projectRootPasswordPrivateState(writeOnly))...)
 
+	r.Data.IDs.invalidate(ProjectResourceName)
 	tflog.Trace(ctx, "created project resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -405,7 +646,12 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	projectModelToResource(project, data)
+	projectModelToResource(r.Data, project, data)
+	writeOnly, diags := req.Private.GetKey(ctx, ProjectPrivateKeyRootPasswordWO)
+	resp.Diagnostics.Append(diags...)
+	if writeOnly != nil {
+		data.RootPassword = types.StringValue(ProjectDefaultValueRootPassword)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -428,13 +674,47 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
-	m := projectResourceToModel(data)
-	_, _, err := r.Data.K.ProjectAPI.UpdateProject(ctx, data.ID.ValueString()).Project(m).Execute()
+	m := projectResourceToModel(r.Data, data)
+	writeOnly := projectWriteOnlyRootPassword(ctx, req.Config, &m, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// teams and regions are updated as a whole, compute the actual delta
+	// against server-side project, which may have drifted since planned
+	current, _, err := r.Data.K.ProjectAPI.ReadProject(ctx, data.ID.ValueString()).Execute()
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
 	}
+	addedRegions, removedRegions := projectDelta(current.Regions, m.Regions)
+	if len(removedRegions) > 0 && data.PreventRemoval.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root(KeyRegions), ProjectErrRegionRemoval, fmt.Sprintf("region(s) %s would be removed from project %s", strings.Join(removedRegions, ", "), data.Name.ValueString()))
+		return
+	}
+	addedTeams, removedTeams := projectDelta(current.Teams, m.Teams)
+	tflog.Info(ctx, "updating project teams and regions", map[string]any{
+		"project":         data.ID.ValueString(),
+		"added_regions":   addedRegions,
+		"removed_regions": removedRegions,
+		"added_teams":     addedTeams,
+		"removed_teams":   removedTeams,
+	})
+
+	_, _, err = r.Data.K.ProjectAPI.UpdateProject(ctx, data.ID.ValueString()).Project(m).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+	data.TagsAll = projectTagsAll(m.Tags)
+	metas := map[string]string{}
+	for _, meta := range m.Metadatas {
+		metas[meta.Key] = meta.Value
+	}
+	data.MetadatasAll = projectMetadatasAll(metas)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ProjectPrivateKeyRootPasswordWO, projectRootPasswordPrivateState(writeOnly))...)
 
+	r.Data.IDs.invalidate(ProjectResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -461,5 +741,6 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(ProjectResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
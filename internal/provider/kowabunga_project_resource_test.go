@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProjectResourcePlanAll(t *testing.T) {
+	ctx := context.Background()
+	r := &ProjectResource{
+		Data: &KowabungaProviderData{
+			DefaultTags:     []string{"env:prod"},
+			DefaultMetadata: map[string]string{"team": "ops", "owner": "infra"},
+		},
+	}
+	s := testResourceSchema(r)
+	stringList := func(values ...string) tftypes.Value {
+		elems := []tftypes.Value{}
+		for _, v := range values {
+			elems = append(elems, tftypes.NewValue(tftypes.String, v))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+	}
+	stringMap := func(values map[string]string) tftypes.Value {
+		elems := map[string]tftypes.Value{}
+		for k, v := range values {
+			elems[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elems)
+	}
+
+	// provider's default tags changed since project was last applied
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: testResourceValue(s, map[string]tftypes.Value{
+			KeyTags:     stringList("web", "app"),
+			KeyMetadata: stringMap(map[string]string{"owner": "web"}),
+		}),
+	}
+	state := tfsdk.State{
+		Schema: s,
+		Raw: testResourceValue(s, map[string]tftypes.Value{
+			KeyTags:        stringList("web", "app"),
+			KeyTagsAll:     stringList("app", "env:dev", "web"),
+			KeyMetadata:    stringMap(map[string]string{"owner": "web"}),
+			KeyMetadataAll: stringMap(map[string]string{"owner": "web"}),
+		}),
+	}
+	req := resource.ModifyPlanRequest{Plan: plan, State: state}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(KeyTagsAll), &tags)...)
+	if want := []string{"app", "env:prod", "web"}; !slices.Equal(tags, want) {
+		t.Errorf("tags_all: got %v, want %v", tags, want)
+	}
+	var metas map[string]string
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(KeyMetadataAll), &metas)...)
+	if want := map[string]string{"team": "ops", "owner": "web"}; !maps.Equal(metas, want) {
+		t.Errorf("metadata_all: got %v, want %v", metas, want)
+	}
+
+	// unknown configured tags
+	plan.Raw = testResourceValue(s, map[string]tftypes.Value{
+		KeyTags:     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+		KeyMetadata: stringMap(nil),
+	})
+	req = resource.ModifyPlanRequest{Plan: plan, State: state}
+	resp = resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+	var tagsAll types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(KeyTagsAll), &tagsAll)...)
+	if resp.Diagnostics.HasError() || !tagsAll.IsUnknown() {
+		t.Errorf("tags_all: got %v, want unknown (%v)", tagsAll, resp.Diagnostics)
+	}
+}
+
+// switching a region reference from ID to name is not a removal
+func TestProjectResourcePlanRegionRemoval(t *testing.T) {
+	ctx := context.Background()
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/region":
+			w.Write([]byte(`["r1"]`))
+		case "/api/v1/region/r1":
+			w.Write([]byte(`{"id":"r1","name":"eu-west","domain":"acme.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	r := &ProjectResource{Data: &KowabungaProviderData{K: k, Mutex: &sync.Mutex{}, IDs: newIDCache(IDCacheDefaultTTL)}}
+	s := testResourceSchema(r)
+	stringList := func(values ...string) tftypes.Value {
+		elems := []tftypes.Value{}
+		for _, v := range values {
+			elems = append(elems, tftypes.NewValue(tftypes.String, v))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+	}
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw: testResourceValue(s, map[string]tftypes.Value{
+			KeyRegions: stringList("r1"),
+		}),
+	}
+	tests := map[string]struct {
+		regions tftypes.Value
+		err     bool
+	}{
+		"same ID":      {regions: stringList("r1")},
+		"name":         {regions: stringList("eu-west")},
+		"removed":      {regions: stringList(), err: true},
+		"other region": {regions: stringList("us-east"), err: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := tfsdk.Plan{
+				Schema: s,
+				Raw: testResourceValue(s, map[string]tftypes.Value{
+					KeyRegions:              tc.regions,
+					KeyPreventRegionRemoval: tftypes.NewValue(tftypes.Bool, true),
+				}),
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+			if got := resp.Diagnostics.HasError(); got != tc.err {
+				t.Errorf("got error %v, want %v (%v)", got, tc.err, resp.Diagnostics)
+			}
+		})
+	}
+}
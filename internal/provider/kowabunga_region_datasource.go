@@ -8,8 +8,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -17,6 +19,13 @@ const (
 	RegionDataSourceName = "region"
 )
 
+type RegionDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Desc   types.String `tfsdk:"desc"`
+	Domain types.String `tfsdk:"domain"`
+}
+
 var _ datasource.DataSource = &RegionDataSource{}
 var _ datasource.DataSourceWithConfigure = &RegionDataSource{}
 
@@ -37,11 +46,31 @@ func (d *RegionDataSource) Configure(ctx context.Context, req datasource.Configu
 }
 
 func (d *RegionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	datasourceFilteredSchema(resp, RegionDataSourceName)
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", RegionDataSourceName),
+		Attributes: map[string]schema.Attribute{
+			KeyID: schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: DataSourceIdDescription,
+			},
+			KeyName: schema.StringAttribute{
+				MarkdownDescription: "Region name or ID",
+				Required:            true,
+			},
+			KeyDesc: schema.StringAttribute{
+				MarkdownDescription: "Region description",
+				Computed:            true,
+			},
+			KeyDomain: schema.StringAttribute{
+				MarkdownDescription: "Region domain name",
+				Computed:            true,
+			},
+		},
+	}
 }
 
 func (d *RegionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data GenericDataSourceModel
+	var data RegionDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -49,18 +78,24 @@ func (d *RegionDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	d.Data.Mutex.Lock()
 	defer d.Data.Mutex.Unlock()
 
-	regions, _, err := d.Data.K.RegionAPI.ListRegions(ctx).Execute()
+	regionId, err := getRegionID(ctx, d.Data, data.Name.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	region, _, err := d.Data.K.RegionAPI.ReadRegion(ctx, regionId).Execute()
 	if err != nil {
 		errorDataSourceReadGeneric(resp, err)
 		return
 	}
-	for _, rg := range regions {
-		r, _, err := d.Data.K.RegionAPI.ReadRegion(ctx, rg).Execute()
-		if err == nil && r.Name == data.Name.ValueString() {
-			data.ID = types.StringPointerValue(r.Id)
-			break
-		}
+
+	data.ID = types.StringPointerValue(region.Id)
+	if region.Description != nil {
+		data.Desc = types.StringPointerValue(region.Description)
+	} else {
+		data.Desc = types.StringValue("")
 	}
+	data.Domain = types.StringValue(region.Domain)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
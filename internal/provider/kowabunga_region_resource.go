@@ -117,6 +117,7 @@ func (r *RegionResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	data.ID = types.StringPointerValue(region.Id)
 	regionModelToResource(region, data) // read back resulting object
+	r.Data.IDs.invalidate(RegionResourceName)
 	tflog.Trace(ctx, "created region resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -172,6 +173,7 @@ func (r *RegionResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	r.Data.IDs.invalidate(RegionResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -198,5 +200,6 @@ func (r *RegionResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(RegionResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -195,7 +195,11 @@ func (r *StorageNfsResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 	// find parent pool (optional)
-	poolId, _ := getPoolID(ctx, r.Data, data.Pool.ValueString())
+	poolId, err := getPoolID(ctx, r.Data, data.Pool.ValueString())
+	if err = optionalReferenceError(r.Data, data.Pool.ValueString(), err); err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
 
 	// create a new NFS storage
 	m := storageNfsResourceToModel(data)
@@ -209,6 +213,11 @@ func (r *StorageNfsResource) Create(ctx context.Context, req resource.CreateRequ
 		errorCreateGeneric(resp, err)
 		return
 	}
+	data.ID = types.StringPointerValue(nfs.Id)
+	storageNfsModelToResource(nfs, data) // read back resulting object
+	if !resourceCreatePending(ctx, resp, &data) {
+		return
+	}
 	// set NFS storage as default
 	if data.Default.ValueBool() {
 		_, err = r.Data.K.RegionAPI.SetRegionDefaultStorageNFS(ctx, regionId, *nfs.Id).Execute()
@@ -217,9 +226,9 @@ func (r *StorageNfsResource) Create(ctx context.Context, req resource.CreateRequ
 			return
 		}
 	}
+	resourceCreateCompleted(ctx, resp)
 
-	data.ID = types.StringPointerValue(nfs.Id)
-	storageNfsModelToResource(nfs, data) // read back resulting object
+	r.Data.IDs.invalidate(StorageNfsResourceName)
 	tflog.Trace(ctx, "created NFS storage resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -230,6 +239,7 @@ func (r *StorageNfsResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
@@ -277,6 +287,7 @@ func (r *StorageNfsResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	r.Data.IDs.invalidate(StorageNfsResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -303,5 +314,6 @@ func (r *StorageNfsResource) Delete(ctx context.Context, req resource.DeleteRequ
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(StorageNfsResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	StoragePoolDataSourceName = "storage_pool"
+)
+
+type StoragePoolDataSourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Desc     types.String   `tfsdk:"desc"`
+	Pool     types.String   `tfsdk:"pool"`
+	Address  types.String   `tfsdk:"address"`
+	Port     types.Int64    `tfsdk:"port"`
+	Price    types.Float64  `tfsdk:"price"`
+	Currency types.String   `tfsdk:"currency"`
+	Agents   []types.String `tfsdk:"agents"`
+}
+
+func storagePoolDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "Storage pool name or ID",
+			Required:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: "Storage pool description",
+			Computed:            true,
+		},
+		KeyPool: schema.StringAttribute{
+			MarkdownDescription: "Ceph RBD pool name",
+			Computed:            true,
+		},
+		KeyAddress: schema.StringAttribute{
+			MarkdownDescription: "Ceph RBD monitor address or hostname",
+			Computed:            true,
+		},
+		KeyPort: schema.Int64Attribute{
+			MarkdownDescription: "Ceph RBD monitor port number",
+			Computed:            true,
+		},
+		KeyPrice: schema.Float64Attribute{
+			MarkdownDescription: "Ceph monthly price value",
+			Computed:            true,
+		},
+		KeyCurrency: schema.StringAttribute{
+			MarkdownDescription: "Ceph monthly price currency",
+			Computed:            true,
+		},
+		KeyAgents: schema.ListAttribute{
+			MarkdownDescription: "Kowabunga remote agents associated with the storage pool",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+var _ datasource.DataSource = &StoragePoolDataSource{}
+var _ datasource.DataSourceWithConfigure = &StoragePoolDataSource{}
+
+func NewStoragePoolDataSource() datasource.DataSource {
+	return &StoragePoolDataSource{}
+}
+
+type StoragePoolDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *StoragePoolDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, StoragePoolDataSourceName)
+}
+
+func (d *StoragePoolDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *StoragePoolDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", StoragePoolDataSourceName),
+		Attributes:          storagePoolDatasourceAttributes(),
+	}
+}
+
+func (d *StoragePoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data StoragePoolDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	poolId, err := getPoolID(ctx, d.Data, data.Name.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	pool, _, err := d.Data.K.PoolAPI.ReadStoragePool(ctx, poolId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	data.ID = types.StringPointerValue(pool.Id)
+	if pool.Description != nil {
+		data.Desc = types.StringPointerValue(pool.Description)
+	} else {
+		data.Desc = types.StringValue("")
+	}
+	data.Pool = types.StringValue(pool.Pool)
+	data.Address = types.StringValue(pool.GetCephAddress())
+	data.Port = types.Int64Value(pool.GetCephPort())
+	data.Price = types.Float64Value(float64(pool.Cost.GetPrice()))
+	data.Currency = types.StringValue(pool.Cost.GetCurrency())
+	data.Agents = []types.String{}
+	for _, a := range pool.Agents {
+		data.Agents = append(data.Agents, types.StringValue(a))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
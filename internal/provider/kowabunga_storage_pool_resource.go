@@ -36,6 +36,7 @@ const (
 
 var _ resource.Resource = &StoragePoolResource{}
 var _ resource.ResourceWithImportState = &StoragePoolResource{}
+var _ resource.ResourceWithModifyPlan = &StoragePoolResource{}
 
 func NewStoragePoolResource() resource.Resource {
 	return &StoragePoolResource{}
@@ -113,10 +114,13 @@ func (r *StoragePoolResource) Schema(ctx context.Context, req resource.SchemaReq
 				Default:             float64default.StaticFloat64(StoragePoolDefaultValuePrice),
 			},
 			KeyCurrency: schema.StringAttribute{
-				MarkdownDescription: "Ceph monthly price currency (default: **EUR**)",
+				MarkdownDescription: "Ceph monthly price ISO-4217 currency code (default: **EUR**). All kaktus nodes and storage pools of a region must share the same currency, unless provider allows mixed currencies.",
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString(StoragePoolDefaultValueCurrency),
+				Validators: []validator.String{
+					&stringCurrencyValidator{},
+				},
 			},
 			KeyDefault: schema.BoolAttribute{
 				MarkdownDescription: "Whether to set pool as region's default one (default: **false**). First pool to be created is always considered as default's one.",
@@ -134,6 +138,42 @@ func (r *StoragePoolResource) Schema(ctx context.Context, req resource.SchemaReq
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
 }
 
+// ensures storage pool's currency matches region's other costs ones
+func (r *StoragePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.Data == nil {
+		return
+	}
+
+	var plan StoragePoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Region.IsUnknown() || plan.Currency.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state StoragePoolResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.Region.Equal(state.Region) && plan.Currency.Equal(state.Currency) {
+			return
+		}
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	regionId, err := getRegionID(ctx, r.Data, plan.Region.ValueString())
+	if err != nil {
+		return // reported at apply time
+	}
+	checkRegionCurrency(ctx, r.Data, regionId, plan.Currency.ValueString(), plan.ID.ValueString(), &resp.Diagnostics)
+}
+
 // converts storage pool from Terraform model to Kowabunga API model
 func storagePoolResourceToModel(d *StoragePoolResourceModel) sdk.StoragePool {
 	cost := &sdk.Cost{
@@ -225,6 +265,11 @@ func (r *StoragePoolResource) Create(ctx context.Context, req resource.CreateReq
 		errorCreateGeneric(resp, err)
 		return
 	}
+	data.ID = types.StringPointerValue(pool.Id)
+	storagePoolModelToResource(pool, data) // read back resulting object
+	if !resourceCreatePending(ctx, resp, &data) {
+		return
+	}
 	// set storage pool as default
 	if data.Default.ValueBool() {
 		_, err = r.Data.K.RegionAPI.SetRegionDefaultStoragePool(ctx, regionId, *pool.Id).Execute()
@@ -233,9 +278,9 @@ func (r *StoragePoolResource) Create(ctx context.Context, req resource.CreateReq
 			return
 		}
 	}
+	resourceCreateCompleted(ctx, resp)
 
-	data.ID = types.StringPointerValue(pool.Id)
-	storagePoolModelToResource(pool, data) // read back resulting object
+	r.Data.IDs.invalidate(StoragePoolResourceName)
 	tflog.Trace(ctx, "created storage pool resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -246,6 +291,7 @@ func (r *StoragePoolResource) Read(ctx context.Context, req resource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
@@ -293,6 +339,7 @@ func (r *StoragePoolResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	r.Data.IDs.invalidate(StoragePoolResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -319,5 +366,6 @@ func (r *StoragePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(StoragePoolResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
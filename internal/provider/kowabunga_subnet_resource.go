@@ -10,16 +10,22 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"net/netip"
 	"strings"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -29,10 +35,31 @@ const (
 
 	SubnetDefaultValueDefault     = false
 	SubnetDefaultValueApplication = "user"
+
+	SubnetErrRange                = "Invalid IPv4 range"
+	SubnetErrRangeFormat          = "range must be formatted as first-last IPv4 addresses"
+	SubnetErrRangeOrder           = "range first address is greater than last one"
+	SubnetErrRangeOutside         = "range does not lie within subnet CIDR"
+	SubnetErrRangeOverlaps        = "gateway pool range overlaps reserved range"
+	SubnetErrRangeOverlapsSibling = "range overlaps another range of the same list"
+	SubnetErrGateway              = "Invalid gateway"
+	SubnetErrGatewayFormat        = "gateway must be an IPv4 address"
+	SubnetErrGatewayOutside       = "gateway does not lie within subnet CIDR"
 )
 
+// subnet application service types, any other one being possibly reported by
+// a newer platform is kept as is in state, only configuration is restricted
+var subnetSupportedApplications = []string{
+	"user",
+	"ceph",
+	"lb",
+	"gateway",
+	"bastion",
+}
+
 var _ resource.Resource = &SubnetResource{}
 var _ resource.ResourceWithImportState = &SubnetResource{}
+var _ resource.ResourceWithValidateConfig = &SubnetResource{}
 
 func NewSubnetResource() resource.Resource {
 	return &SubnetResource{}
@@ -81,9 +108,12 @@ func (r *SubnetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			KeyCIDR: schema.StringAttribute{
 				MarkdownDescription: "Subnet CIDR",
 				Required:            true,
+				Validators: []validator.String{
+					&stringNetworkCIDRValidator{},
+				},
 			},
 			KeyGateway: schema.StringAttribute{
-				MarkdownDescription: "Subnet router/gateway",
+				MarkdownDescription: "Subnet router/gateway, IPv4 address within subnet CIDR",
 				Required:            true,
 			},
 			KeyDNS: schema.StringAttribute{
@@ -91,12 +121,12 @@ func (r *SubnetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:            true,
 			},
 			KeyReserved: schema.ListAttribute{
-				MarkdownDescription: "List of subnet's reserved IPv4 ranges (format: 192.168.0.200-192.168.0.240). IPv4 addresses from these ranges cannot be used by Kowabunga to assign resources.",
+				MarkdownDescription: "List of subnet's reserved IPv4 ranges (format: 192.168.0.200-192.168.0.240), within subnet CIDR and not overlapping each other. IPv4 addresses from these ranges cannot be used by Kowabunga to assign resources.",
 				Required:            true,
 				ElementType:         types.StringType,
 			},
 			KeyGwPool: schema.ListAttribute{
-				MarkdownDescription: "Subnet's range of IPv4 addresses reserved for local zone's network gateway (format: 192.168.0.200-192.168.0.240). Range size must be at least equal to region's number of zones.",
+				MarkdownDescription: "Subnet's range of IPv4 addresses reserved for local zone's network gateway (format: 192.168.0.200-192.168.0.240). Range size must be at least equal to region's number of zones. Ranges must lie within subnet CIDR and must not overlap reserved ones.",
 				Required:            true,
 				ElementType:         types.StringType,
 			},
@@ -104,12 +134,18 @@ func (r *SubnetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "List of extra routes to be access through designated gateway (format: 10.0.0.0/8).",
 				Required:            true,
 				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(&stringNetworkCIDRValidator{}),
+				},
 			},
 			KeyApplication: schema.StringAttribute{
-				MarkdownDescription: "Optional application service type (defaults to 'user', possible values: 'user', 'ceph').",
+				MarkdownDescription: "Optional application service type (defaults to 'user', possible values: 'user', 'ceph', or 'lb', 'gateway' and 'bastion' service subnets, on platforms supporting them).",
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString(SubnetDefaultValueApplication),
+				Validators: []validator.String{
+					stringvalidator.OneOf(subnetSupportedApplications...),
+				},
 			},
 			KeyDefault: schema.BoolAttribute{
 				MarkdownDescription: "Whether to set subnet as virtual network's default one (default: **false**). The first subnet to be created is always considered as default one.",
@@ -122,6 +158,99 @@ func (r *SubnetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	maps.Copy(resp.Schema.Attributes, resourceAttributes(&ctx))
 }
 
+type subnetRange struct {
+	path  path.Path
+	first netip.Addr
+	last  netip.Addr
+}
+
+func (r subnetRange) overlaps(o subnetRange) bool {
+	return r.first.Compare(o.last) <= 0 && o.first.Compare(r.last) <= 0
+}
+
+// parses and validates a list of IPv4 ranges against subnet's CIDR (if known)
+func subnetValidateRanges(ranges types.List, key string, cidr *netip.Prefix, diags *diag.Diagnostics) []subnetRange {
+	res := []subnetRange{}
+	if ranges.IsNull() || ranges.IsUnknown() {
+		return res
+	}
+
+	for idx, item := range ranges.Elements() {
+		p := path.Root(key).AtListIndex(idx)
+		value, ok := item.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+
+		split := strings.Split(value.ValueString(), "-")
+		if len(split) != 2 {
+			diags.AddAttributeError(p, SubnetErrRange, fmt.Sprintf("%s: %s", SubnetErrRangeFormat, value.ValueString()))
+			continue
+		}
+		first, err1 := netip.ParseAddr(strings.TrimSpace(split[0]))
+		last, err2 := netip.ParseAddr(strings.TrimSpace(split[1]))
+		if err1 != nil || err2 != nil || !first.Is4() || !last.Is4() {
+			diags.AddAttributeError(p, SubnetErrRange, fmt.Sprintf("%s: %s", SubnetErrRangeFormat, value.ValueString()))
+			continue
+		}
+		if first.Compare(last) > 0 {
+			diags.AddAttributeError(p, SubnetErrRange, fmt.Sprintf("%s: %s", SubnetErrRangeOrder, value.ValueString()))
+			continue
+		}
+		if cidr != nil && (!cidr.Contains(first) || !cidr.Contains(last)) {
+			diags.AddAttributeError(p, SubnetErrRange, fmt.Sprintf("%s %s: %s", SubnetErrRangeOutside, cidr.String(), value.ValueString()))
+			continue
+		}
+
+		sr := subnetRange{path: p, first: first, last: last}
+		for _, other := range res {
+			if sr.overlaps(other) {
+				diags.AddAttributeError(p, SubnetErrRange, fmt.Sprintf("%s: %s-%s", SubnetErrRangeOverlapsSibling, other.first, other.last))
+			}
+		}
+		res = append(res, sr)
+	}
+	return res
+}
+
+// ensures gateway, reserved and gateway pool ranges lie within subnet's CIDR and ranges do not overlap
+func (r *SubnetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SubnetResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var cidr *netip.Prefix
+	if !data.CIDR.IsUnknown() && !data.CIDR.IsNull() {
+		prefix, err := netip.ParsePrefix(data.CIDR.ValueString())
+		if err == nil {
+			prefix = prefix.Masked()
+			cidr = &prefix
+		}
+	}
+
+	if !data.Gateway.IsUnknown() && !data.Gateway.IsNull() {
+		gw, err := netip.ParseAddr(data.Gateway.ValueString())
+		if err != nil || !gw.Is4() {
+			resp.Diagnostics.AddAttributeError(path.Root(KeyGateway), SubnetErrGateway, fmt.Sprintf("%s: %s", SubnetErrGatewayFormat, data.Gateway.ValueString()))
+		} else if cidr != nil && !cidr.Contains(gw) {
+			resp.Diagnostics.AddAttributeError(path.Root(KeyGateway), SubnetErrGateway, fmt.Sprintf("%s %s: %s", SubnetErrGatewayOutside, cidr.String(), gw))
+		}
+	}
+
+	reserved := subnetValidateRanges(data.Reserved, KeyReserved, cidr, &resp.Diagnostics)
+	gwPool := subnetValidateRanges(data.GwPool, KeyGwPool, cidr, &resp.Diagnostics)
+
+	for _, gw := range gwPool {
+		for _, rs := range reserved {
+			if gw.overlaps(rs) {
+				resp.Diagnostics.AddAttributeError(gw.path, SubnetErrRange, fmt.Sprintf("%s %s-%s", SubnetErrRangeOverlaps, rs.first, rs.last))
+			}
+		}
+	}
+}
+
 // converts subnet from Terraform model to Kowabunga API model
 func subnetResourceToModel(d *SubnetResourceModel) sdk.Subnet {
 	reservedRanges := []sdk.IpRange{}
@@ -248,6 +377,11 @@ func (r *SubnetResource) Create(ctx context.Context, req resource.CreateRequest,
 		errorCreateGeneric(resp, err)
 		return
 	}
+	data.ID = types.StringPointerValue(subnet.Id)
+	//subnetModelToResource(vnet, data) // read back resulting object
+	if !resourceCreatePending(ctx, resp, &data) {
+		return
+	}
 	// set virtual network as default
 	if data.Default.ValueBool() {
 		_, err = r.Data.K.VnetAPI.SetVNetDefaultSubnet(ctx, vnetId, *subnet.Id).Execute()
@@ -256,9 +390,9 @@ func (r *SubnetResource) Create(ctx context.Context, req resource.CreateRequest,
 			return
 		}
 	}
+	resourceCreateCompleted(ctx, resp)
 
-	data.ID = types.StringPointerValue(subnet.Id)
-	//subnetModelToResource(vnet, data) // read back resulting object
+	r.Data.IDs.invalidate(SubnetResourceName)
 	tflog.Trace(ctx, "created subnet resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -269,6 +403,7 @@ func (r *SubnetResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
@@ -316,6 +451,7 @@ func (r *SubnetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	r.Data.IDs.invalidate(SubnetResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -342,5 +478,6 @@ func (r *SubnetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(SubnetResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -8,15 +8,48 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
 	TeamDataSourceName = "team"
+
+	TeamDataSourceErrNotFound = "no such team"
 )
 
+type TeamDataSourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Desc  types.String `tfsdk:"desc"`
+	Users types.List   `tfsdk:"users"`
+}
+
+func teamDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "Team name or ID",
+			Required:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: "Team description",
+			Computed:            true,
+		},
+		KeyUsers: schema.ListAttribute{
+			MarkdownDescription: "The list of IDs of users member of the team",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
 var _ datasource.DataSource = &TeamDataSource{}
 var _ datasource.DataSourceWithConfigure = &TeamDataSource{}
 
@@ -37,11 +70,14 @@ func (d *TeamDataSource) Configure(ctx context.Context, req datasource.Configure
 }
 
 func (d *TeamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	datasourceFilteredSchema(resp, TeamDataSourceName)
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", TeamDataSourceName),
+		Attributes:          teamDatasourceAttributes(),
+	}
 }
 
 func (d *TeamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data GenericDataSourceModel
+	var data TeamDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -56,10 +92,21 @@ func (d *TeamDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 	for _, rg := range groups {
 		r, _, err := d.Data.K.TeamAPI.ReadTeam(ctx, rg).Execute()
-		if err == nil && r.Name == data.Name.ValueString() {
-			data.ID = types.StringPointerValue(r.Id)
-			break
+		if err != nil || (r.Name != data.Name.ValueString() && r.GetId() != data.Name.ValueString()) {
+			continue
 		}
+
+		data.ID = types.StringPointerValue(r.Id)
+		data.Desc = types.StringValue(r.GetDescription())
+		users, diags := types.ListValueFrom(ctx, types.StringType, r.Users)
+		resp.Diagnostics.Append(diags...)
+		data.Users = users
+		break
+	}
+
+	if data.ID.IsNull() {
+		resp.Diagnostics.AddError(ErrorGeneric, fmt.Sprintf("%s: %s", TeamDataSourceErrNotFound, data.Name.ValueString()))
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	TemplateDataSourceName = "template"
+
+	TemplateDataSourceErrAmbiguous = "several templates are named '%s' across storage pools, set 'pool' to pick one"
+)
+
+type TemplateDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Pool   types.String `tfsdk:"pool"`
+	Desc   types.String `tfsdk:"desc"`
+	OS     types.String `tfsdk:"os"`
+	Source types.String `tfsdk:"source"`
+}
+
+func templateDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "Volume template name or ID",
+			Required:            true,
+		},
+		KeyPool: schema.StringAttribute{
+			MarkdownDescription: "Storage pool name or ID the template belongs to. Required to disambiguate template name if several storage pools have a template with this name",
+			Optional:            true,
+		},
+		KeyDesc: schema.StringAttribute{
+			MarkdownDescription: "Volume template description",
+			Computed:            true,
+		},
+		KeyOS: schema.StringAttribute{
+			MarkdownDescription: "Volume template operating system type",
+			Computed:            true,
+		},
+		KeySource: schema.StringAttribute{
+			MarkdownDescription: "Volume template source image URL",
+			Computed:            true,
+		},
+	}
+}
+
+var _ datasource.DataSource = &TemplateDataSource{}
+var _ datasource.DataSourceWithConfigure = &TemplateDataSource{}
+
+func NewTemplateDataSource() datasource.DataSource {
+	return &TemplateDataSource{}
+}
+
+type TemplateDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *TemplateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, TemplateDataSourceName)
+}
+
+func (d *TemplateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *TemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", TemplateDataSourceName),
+		Attributes:          templateDatasourceAttributes(),
+	}
+}
+
+// finds template's ID from its ID or name, across all storage pools, the
+// latter having to be unique
+func (d *TemplateDataSource) lookup(ctx context.Context, name string) (string, error) {
+	t, _, err := d.Data.K.TemplateAPI.ReadTemplate(ctx, name).Execute()
+	if err == nil {
+		return t.GetId(), nil
+	}
+
+	templates, _, err := d.Data.K.TemplateAPI.ListTemplates(ctx).Execute()
+	if err != nil {
+		return "", err
+	}
+	matches := []string{}
+	for _, tn := range templates {
+		t, _, err := d.Data.K.TemplateAPI.ReadTemplate(ctx, tn).Execute()
+		if err == nil && t.Name == name {
+			matches = append(matches, t.GetId())
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%s", ErrorUnknownTemplate)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf(TemplateDataSourceErrAmbiguous, name)
+	}
+}
+
+func (d *TemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TemplateDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	var templateId string
+	var err error
+	if data.Pool.ValueString() != "" {
+		var poolId string
+		poolId, err = getPoolID(ctx, d.Data, data.Pool.ValueString())
+		if err != nil {
+			errorDataSourceReadGeneric(resp, err)
+			return
+		}
+		templateId, err = getTemplateID(ctx, d.Data, data.Name.ValueString(), poolId)
+	} else {
+		templateId, err = d.lookup(ctx, data.Name.ValueString())
+	}
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	template, _, err := d.Data.K.TemplateAPI.ReadTemplate(ctx, templateId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	data.ID = types.StringPointerValue(template.Id)
+	if template.Description != nil {
+		data.Desc = types.StringPointerValue(template.Description)
+	} else {
+		data.Desc = types.StringValue("")
+	}
+	if template.Os != nil {
+		data.OS = types.StringPointerValue(template.Os)
+	} else {
+		data.OS = types.StringValue(TemplateDefaultValueOS)
+	}
+	data.Source = types.StringValue(template.Source)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -13,10 +13,12 @@ import (
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -28,6 +30,11 @@ const (
 	TemplateDefaultValueDefault = false
 )
 
+var templateSupportedOS = []string{
+	"linux",
+	"windows",
+}
+
 var _ resource.Resource = &TemplateResource{}
 var _ resource.ResourceWithImportState = &TemplateResource{}
 
@@ -75,6 +82,9 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString(TemplateDefaultValueOS),
+				Validators: []validator.String{
+					stringvalidator.OneOf(templateSupportedOS...),
+				},
 			},
 			KeySource: schema.StringAttribute{
 				MarkdownDescription: "The template HTTP(S) source URL.",
@@ -152,6 +162,11 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		errorCreateGeneric(resp, err)
 		return
 	}
+	data.ID = types.StringPointerValue(template.Id)
+	templateModelToResource(template, data) // read back resulting object
+	if !resourceCreatePending(ctx, resp, &data) {
+		return
+	}
 	// set template as default
 	if data.Default.ValueBool() {
 		_, err = r.Data.K.PoolAPI.SetStoragePoolDefaultTemplate(ctx, poolId, *template.Id).Execute()
@@ -160,9 +175,9 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 			return
 		}
 	}
+	resourceCreateCompleted(ctx, resp)
 
-	data.ID = types.StringPointerValue(template.Id)
-	templateModelToResource(template, data) // read back resulting object
+	r.Data.IDs.invalidate(TemplateResourceName)
 	tflog.Trace(ctx, "created template resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -173,6 +188,7 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
@@ -218,6 +234,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	r.Data.IDs.invalidate(TemplateResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -244,5 +261,6 @@ func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteReques
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(TemplateResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
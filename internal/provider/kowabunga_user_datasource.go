@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	UserDataSourceName = "user"
+
+	UserDataSourceErrNotFound = "no such user"
+)
+
+type UserDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Email         types.String `tfsdk:"email"`
+	Role          types.String `tfsdk:"role"`
+	Notifications types.Bool   `tfsdk:"notifications"`
+	Teams         types.List   `tfsdk:"teams"`
+}
+
+func userDatasourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		KeyID: schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: DataSourceIdDescription,
+		},
+		KeyName: schema.StringAttribute{
+			MarkdownDescription: "User name or ID to look up (exclusive with email)",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot(KeyEmail)),
+			},
+		},
+		KeyEmail: schema.StringAttribute{
+			MarkdownDescription: "User email address to look up (exclusive with name, case-insensitive)",
+			Optional:            true,
+			Computed:            true,
+		},
+		KeyRole: schema.StringAttribute{
+			MarkdownDescription: "Kowabunga user role (" + strings.Join(userSupportedRoles, ", ") + ")",
+			Computed:            true,
+		},
+		KeyNotifications: schema.BoolAttribute{
+			MarkdownDescription: "Whether Kowabunga user wants email notifications on events",
+			Computed:            true,
+		},
+		KeyTeams: schema.ListAttribute{
+			MarkdownDescription: "The list of IDs of teams the user is member of",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigure = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+type UserDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, UserDataSourceName)
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource, looked up by name or email", UserDataSourceName),
+		Attributes:          userDatasourceAttributes(),
+	}
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	name := data.Name.ValueString()
+	email := data.Email.ValueString()
+
+	users, _, err := d.Data.K.UserAPI.ListUsers(ctx).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	for _, u := range users {
+		r, _, err := d.Data.K.UserAPI.ReadUser(ctx, u).Execute()
+		if err != nil {
+			continue
+		}
+		if name != "" && r.Name != name && r.GetId() != name {
+			continue
+		}
+		if email != "" && !strings.EqualFold(r.Email, email) {
+			continue
+		}
+
+		data.ID = types.StringPointerValue(r.Id)
+		data.Name = types.StringValue(r.Name)
+		data.Email = types.StringValue(r.Email)
+		data.Role = types.StringValue(r.Role)
+		data.Notifications = types.BoolValue(r.GetNotifications())
+		break
+	}
+
+	if data.ID.IsNull() {
+		resp.Diagnostics.AddError(ErrorGeneric, fmt.Sprintf("%s: %s%s", UserDataSourceErrNotFound, name, email))
+		return
+	}
+
+	// teams membership is only known from teams side
+	groups, _, err := d.Data.K.TeamAPI.ListTeams(ctx).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	teams := []string{}
+	for _, rg := range groups {
+		t, _, err := d.Data.K.TeamAPI.ReadTeam(ctx, rg).Execute()
+		if err != nil {
+			continue
+		}
+		if slices.Contains(t.Users, data.ID.ValueString()) {
+			teams = append(teams, t.GetId())
+		}
+	}
+	list, diags := types.ListValueFrom(ctx, types.StringType, teams)
+	resp.Diagnostics.Append(diags...)
+	data.Teams = list
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
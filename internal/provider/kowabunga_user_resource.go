@@ -88,7 +88,7 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Default:             booldefault.StaticBool(UserDefaultValueNotifications),
 			},
 			KeyBot: schema.BoolAttribute{
-				MarkdownDescription: "Whether Kowabunga user is actually a robot account (default: **false**)",
+				MarkdownDescription: "Whether Kowabunga user is actually a robot account (default: **false**). Robot accounts get an API key, regular ones a password, both sent by email. Role changes keep existing credentials, switching account kind issues new ones",
 				Computed:            true,
 				Optional:            true,
 				Default:             booldefault.StaticBool(UserDefaultValueBot),
@@ -124,6 +124,17 @@ func userModelToResource(r *sdk.User, d *UserResourceModel) {
 	}
 }
 
+// requests server to generate user's credentials, sent by email: a new API
+// key for robot accounts, a new password for regular ones
+func (r *UserResource) issueCredentials(ctx context.Context, id string, bot bool) error {
+	if bot {
+		_, err := r.Data.K.UserAPI.SetUserApiToken(ctx, id).Execute()
+		return err
+	}
+	_, err := r.Data.K.UserAPI.ResetUserPassword(ctx, id).Execute()
+	return err
+}
+
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *UserResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -150,22 +161,16 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	data.ID = types.StringPointerValue(user.Id)
 	userModelToResource(user, data) // read back resulting object
+	if !resourceCreatePending(ctx, resp, &data) {
+		return
+	}
 
-	if data.Bot.ValueBool() {
-		// request server to generate a new robot API key, will be sent by email
-		_, err = r.Data.K.UserAPI.SetUserApiToken(ctx, *user.Id).Execute()
-		if err != nil {
-			errorCreateGeneric(resp, err)
-			return
-		}
-	} else {
-		// request server to generate a new user password, will be sent by email
-		_, err = r.Data.K.UserAPI.ResetUserPassword(ctx, *user.Id).Execute()
-		if err != nil {
-			errorCreateGeneric(resp, err)
-			return
-		}
+	err = r.issueCredentials(ctx, *user.Id, data.Bot.ValueBool())
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
 	}
+	resourceCreateCompleted(ctx, resp)
 
 	tflog.Trace(ctx, "created user resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -177,6 +182,7 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resourceCreationPending(ctx, req, data.ID.ValueString())
 	timeout, diags := data.Timeouts.Read(ctx, DefaultReadTimeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -199,8 +205,9 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *UserResourceModel
+	var data, state *UserResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -216,11 +223,22 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	defer r.Data.Mutex.Unlock()
 
 	m := userResourceToModel(data)
-	_, _, err := r.Data.K.UserAPI.UpdateUser(ctx, data.ID.ValueString()).User(m).Execute()
+	user, _, err := r.Data.K.UserAPI.UpdateUser(ctx, data.ID.ValueString()).User(m).Execute()
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
 	}
+	userModelToResource(user, data) // read back resulting object
+
+	// role or profile changes leave existing credentials untouched, only
+	// switching account kind (regular user <-> robot) issues new ones
+	if data.Bot.ValueBool() != state.Bot.ValueBool() {
+		err = r.issueCredentials(ctx, data.ID.ValueString(), data.Bot.ValueBool())
+		if err != nil {
+			errorUpdateGeneric(resp, err)
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
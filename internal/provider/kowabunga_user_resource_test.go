@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUserResourceUpdateRoleTransitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		priorRole string
+		priorBot  bool
+		role      string
+		bot       bool
+		wantCalls []string
+	}{
+		{
+			name:      "user role change keeps password",
+			priorRole: "user", role: "admin",
+			wantCalls: []string{"PUT /api/v1/user/u1"},
+		},
+		{
+			name:      "robot role change keeps API key",
+			priorRole: "project_admin", priorBot: true, role: "user", bot: true,
+			wantCalls: []string{"PUT /api/v1/user/u1"},
+		},
+		{
+			name:      "user turned into robot gets an API key",
+			priorRole: "user", role: "user", bot: true,
+			wantCalls: []string{"PUT /api/v1/user/u1", "PATCH /api/v1/user/u1/token"},
+		},
+		{
+			name:      "robot turned into user gets a password",
+			priorRole: "admin", priorBot: true, role: "admin",
+			wantCalls: []string{"PUT /api/v1/user/u1", "PATCH /api/v1/user/u1/resetPassword"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			calls := []string{}
+			k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				calls = append(calls, req.Method+" "+req.URL.Path)
+				if req.Method != http.MethodPut {
+					return
+				}
+				var user sdk.User
+				_ = json.NewDecoder(req.Body).Decode(&user)
+				user.Id = sdk.PtrString("u1")
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(user)
+			})
+			r := &UserResource{Data: &KowabungaProviderData{K: k, Mutex: &sync.Mutex{}}}
+			s := testResourceSchema(r)
+
+			value := func(role string, bot bool) tftypes.Value {
+				return testResourceValue(s, map[string]tftypes.Value{
+					KeyID:            tftypes.NewValue(tftypes.String, "u1"),
+					KeyName:          tftypes.NewValue(tftypes.String, "john"),
+					KeyDesc:          tftypes.NewValue(tftypes.String, ""),
+					KeyEmail:         tftypes.NewValue(tftypes.String, "john@acme.com"),
+					KeyRole:          tftypes.NewValue(tftypes.String, role),
+					KeyNotifications: tftypes.NewValue(tftypes.Bool, false),
+					KeyBot:           tftypes.NewValue(tftypes.Bool, bot),
+				})
+			}
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: s, Raw: value(tt.role, tt.bot)},
+				State: tfsdk.State{Schema: s, Raw: value(tt.priorRole, tt.priorBot)},
+			}
+			resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: req.Plan.Raw}}
+			r.Update(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("API calls: got %v, want %v", calls, tt.wantCalls)
+			}
+			var data UserResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.Role.ValueString() != tt.role || data.Bot.ValueBool() != tt.bot {
+				t.Errorf("state: got role %s and bot %t, want %s and %t", data.Role.ValueString(), data.Bot.ValueBool(), tt.role, tt.bot)
+			}
+		})
+	}
+}
@@ -163,6 +163,7 @@ func (r *VNetResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	data.ID = types.StringPointerValue(vnet.Id)
 	vnetModelToResource(vnet, data) // read back resulting object
+	r.Data.IDs.invalidate(VNetResourceName)
 	tflog.Trace(ctx, "created vnet resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -220,6 +221,7 @@ func (r *VNetResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	r.Data.IDs.invalidate(VNetResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -246,5 +248,6 @@ func (r *VNetResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(VNetResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	VNetSubnetsDataSourceName = "vnet_subnets"
+)
+
+type VNetSubnetsDataSourceModel struct {
+	ID      types.String                `tfsdk:"id"`
+	VNet    types.String                `tfsdk:"vnet"`
+	Subnets []VNetSubnetDataSourceModel `tfsdk:"subnets"`
+}
+
+type VNetSubnetDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	CIDR        types.String `tfsdk:"cidr"`
+	Application types.String `tfsdk:"application"`
+}
+
+var _ datasource.DataSource = &VNetSubnetsDataSource{}
+var _ datasource.DataSourceWithConfigure = &VNetSubnetsDataSource{}
+
+func NewVNetSubnetsDataSource() datasource.DataSource {
+	return &VNetSubnetsDataSource{}
+}
+
+type VNetSubnetsDataSource struct {
+	Data *KowabungaProviderData
+}
+
+func (d *VNetSubnetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	datasourceMetadata(req, resp, VNetSubnetsDataSourceName)
+}
+
+func (d *VNetSubnetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.Data = datasourceConfigure(req, resp)
+}
+
+func (d *VNetSubnetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the subnets of a virtual network, sorted by name. Virtual network's default subnet is not reported by Kowabunga API, use `kowabunga_subnet` application lookup instead.",
+		Attributes: map[string]schema.Attribute{
+			KeyID: schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Virtual network ID",
+			},
+			KeyVNet: schema.StringAttribute{
+				MarkdownDescription: "Virtual network name or ID",
+				Required:            true,
+			},
+			KeySubnets: schema.ListNestedAttribute{
+				MarkdownDescription: "Virtual network subnets",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						KeyID: schema.StringAttribute{
+							MarkdownDescription: "Subnet ID",
+							Computed:            true,
+						},
+						KeyName: schema.StringAttribute{
+							MarkdownDescription: "Subnet name",
+							Computed:            true,
+						},
+						KeyCIDR: schema.StringAttribute{
+							MarkdownDescription: "Subnet CIDR",
+							Computed:            true,
+						},
+						KeyApplication: schema.StringAttribute{
+							MarkdownDescription: "Subnet application",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *VNetSubnetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VNetSubnetsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.Data.Mutex.Lock()
+	defer d.Data.Mutex.Unlock()
+
+	vnetId, err := getVNetID(ctx, d.Data, data.VNet.ValueString())
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	subnetIds, _, err := d.Data.K.VnetAPI.ListVNetSubnets(ctx, vnetId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	subnets := []*sdk.Subnet{}
+	for _, id := range subnetIds {
+		s, _, err := d.Data.K.SubnetAPI.ReadSubnet(ctx, id).Execute()
+		if err != nil {
+			errorDataSourceReadGeneric(resp, err)
+			return
+		}
+		subnets = append(subnets, s)
+	}
+	slices.SortFunc(subnets, func(a, b *sdk.Subnet) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	data.ID = types.StringValue(vnetId)
+	data.Subnets = []VNetSubnetDataSourceModel{}
+	for _, s := range subnets {
+		application := SubnetDefaultValueApplication
+		if s.Application != nil {
+			application = *s.Application
+		}
+		data.Subnets = append(data.Subnets, VNetSubnetDataSourceModel{
+			ID:          types.StringPointerValue(s.Id),
+			Name:        types.StringValue(s.Name),
+			CIDR:        types.StringValue(s.Cidr),
+			Application: types.StringValue(application),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
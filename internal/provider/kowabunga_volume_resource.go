@@ -13,8 +13,12 @@ import (
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -23,8 +27,15 @@ const (
 	VolumeResourceName = "volume"
 )
 
+var volumeSupportedTypes = []string{
+	"os",
+	"iso",
+	"raw",
+}
+
 var _ resource.Resource = &VolumeResource{}
 var _ resource.ResourceWithImportState = &VolumeResource{}
+var _ resource.ResourceWithModifyPlan = &VolumeResource{}
 
 func NewVolumeResource() resource.Resource {
 	return &VolumeResource{}
@@ -44,7 +55,7 @@ type VolumeResourceModel struct {
 	Pool     types.String   `tfsdk:"pool"`
 	Template types.String   `tfsdk:"template"`
 	Type     types.String   `tfsdk:"type"`
-	Size     types.Int64    `tfsdk:"size"`
+	Size     SizeGBValue    `tfsdk:"size"`
 }
 
 func (r *VolumeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -76,16 +87,26 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 			},
 			KeyType: schema.StringAttribute{
-				MarkdownDescription: "The volume type (valid options: 'os', 'iso', 'raw')",
+				MarkdownDescription: "The volume type (valid options: 'os', 'iso', 'raw'). Volume can't be converted from one type to another, changing it forces a new resource to be created.",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(volumeSupportedTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			KeyTemplate: schema.StringAttribute{
-				MarkdownDescription: "The template name or ID",
+				MarkdownDescription: "The template name or ID the volume is populated from (e.g. a template whose source is an ISO image for 'iso' volumes)",
 				Optional:            true,
 			},
 			KeySize: schema.Int64Attribute{
-				MarkdownDescription: "The volume size (expressed in GB)",
+				MarkdownDescription: "The volume size (expressed in GB). Volume is resized in place and can only grow, a size not greater than the actual one (e.g. volume grown beyond it from a larger template) plans no change.",
 				Required:            true,
+				CustomType:          SizeGBType{},
+				PlanModifiers: []planmodifier.Int64{
+					int64GrowOnlyModifier{},
+				},
 			},
 		},
 	}
@@ -98,7 +119,7 @@ func volumeResourceToModel(d *VolumeResourceModel) sdk.Volume {
 		Name:        d.Name.ValueString(),
 		Description: d.Desc.ValueStringPointer(),
 		Type:        d.Type.ValueString(),
-		Size:        d.Size.ValueInt64() * HelperGbToBytes,
+		Size:        d.Size.ValueBytes(),
 	}
 }
 
@@ -111,7 +132,28 @@ func volumeModelToResource(r *sdk.Volume, d *VolumeResourceModel) {
 		d.Desc = types.StringValue("")
 	}
 	d.Type = types.StringValue(r.Type)
-	d.Size = types.Int64Value(r.Size / HelperGbToBytes)
+	d.Size = sizeGBGrowOnlyValue(d.Size, NewSizeGBValueFromBytes(r.Size))
+}
+
+// checks project quotas at plan time on creation
+func (r *VolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.Data == nil {
+		return
+	}
+
+	var plan VolumeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Size.IsUnknown() {
+		return
+	}
+
+	r.Data.Mutex.Lock()
+	defer r.Data.Mutex.Unlock()
+
+	m := volumeResourceToModel(&plan)
+	planProjectQuotas(ctx, r.Data, plan.Project, sdk.ProjectResources{
+		Storage: &m.Size,
+	}, &resp.Diagnostics)
 }
 
 func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -145,13 +187,29 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	// find parent pool (optional)
-	poolId, _ := getPoolID(ctx, r.Data, data.Pool.ValueString())
-
+	poolId, err := getPoolID(ctx, r.Data, data.Pool.ValueString())
+	if err = optionalReferenceError(r.Data, data.Pool.ValueString(), err); err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
 	// find parent template (optional)
-	templateId, _ := getTemplateID(ctx, r.Data, data.Template.ValueString(), poolId)
+	templateId, err := getTemplateID(ctx, r.Data, data.Template.ValueString(), poolId)
+	if err = optionalReferenceError(r.Data, data.Template.ValueString(), err); err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
 
-	// create a new volume
+	// ensure project has enough storage left before creating anything
 	m := volumeResourceToModel(data)
+	err = checkProjectQuotas(ctx, r.Data, projectId, sdk.ProjectResources{
+		Storage: &m.Size,
+	})
+	if err != nil {
+		errorCreateGeneric(resp, err)
+		return
+	}
+
+	// create a new volume
 	api := r.Data.K.ProjectAPI.CreateProjectRegionVolume(ctx, projectId, regionId).Volume(m)
 	if poolId != "" {
 		api = api.PoolId(poolId)
@@ -166,6 +224,7 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	data.ID = types.StringPointerValue(volume.Id)
 	volumeModelToResource(volume, data) // read back resulting object
+	resp.Diagnostics.Append(growOnlySetActual(ctx, resp.Private, KeySize, NewSizeGBValueFromBytes(volume.Size))...)
 	tflog.Trace(ctx, "created volume resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -195,6 +254,7 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	volumeModelToResource(volume, data)
+	resp.Diagnostics.Append(growOnlySetActual(ctx, resp.Private, KeySize, NewSizeGBValueFromBytes(volume.Size))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -214,12 +274,21 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	r.Data.Mutex.Lock()
 	defer r.Data.Mutex.Unlock()
 
+	volume, _, err := r.Data.K.VolumeAPI.ReadVolume(ctx, data.ID.ValueString()).Execute()
+	if err != nil {
+		errorUpdateGeneric(resp, err)
+		return
+	}
+
+	// never request a volume smaller than actual one
 	m := volumeResourceToModel(data)
-	_, _, err := r.Data.K.VolumeAPI.UpdateVolume(ctx, data.ID.ValueString()).Volume(m).Execute()
+	m.Size = max(m.Size, volume.Size)
+	_, _, err = r.Data.K.VolumeAPI.UpdateVolume(ctx, data.ID.ValueString()).Volume(m).Execute()
 	if err != nil {
 		errorUpdateGeneric(resp, err)
 		return
 	}
+	resp.Diagnostics.Append(growOnlySetActual(ctx, resp.Private, KeySize, NewSizeGBValueFromBytes(m.Size))...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// plans a volume resize from a 10GB state, with actual volume size recorded
+// in private state
+func planVolumeResize(t *testing.T, size int64, actual string) *tfprotov6.PlanResourceChangeResponse {
+	ctx := context.Background()
+
+	s := testResourceSchema(NewVolumeResource())
+	volume := func(size int64, id any) *tfprotov6.DynamicValue {
+		value := testResourceValue(s, map[string]tftypes.Value{
+			KeyID:      tftypes.NewValue(tftypes.String, id),
+			KeyName:    tftypes.NewValue(tftypes.String, "data"),
+			KeyDesc:    tftypes.NewValue(tftypes.String, ""),
+			KeyProject: tftypes.NewValue(tftypes.String, "project"),
+			KeyRegion:  tftypes.NewValue(tftypes.String, "region"),
+			KeyType:    tftypes.NewValue(tftypes.String, "raw"),
+			KeySize:    tftypes.NewValue(tftypes.Number, size),
+		})
+		v, err := tfprotov6.NewDynamicValue(value.Type(), value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &v
+	}
+
+	private, err := json.Marshal(map[string][]byte{
+		planModifierGrowOnlyPrivateKeyPrefix + KeySize: []byte(actual),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := providerserver.NewProtocol6(New("test")())()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         ProviderName + "_" + VolumeResourceName,
+		PriorState:       volume(10, "volume-id"),
+		ProposedNewState: volume(size, "volume-id"),
+		Config:           volume(size, nil),
+		PriorPrivate:     private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestVolumeResourcePlanShrink(t *testing.T) {
+	s := testResourceSchema(NewVolumeResource())
+	objType := s.Type().TerraformType(context.Background())
+
+	tests := []struct {
+		name   string
+		size   int64
+		actual string
+		want   int64
+		warn   bool
+	}{
+		{"unchanged, grown from template", 10, "20", 10, false},
+		{"shrink", 5, "10", 10, true},
+		{"grow below actual size", 15, "20", 10, false},
+		{"grow beyond actual size", 25, "20", 25, false},
+	}
+	for _, tt := range tests {
+		resp := planVolumeResize(t, tt.size, tt.actual)
+		warn := false
+		for _, d := range resp.Diagnostics {
+			switch {
+			case d.Severity == tfprotov6.DiagnosticSeverityWarning && d.Summary == PlanModifierGrowOnlyWarnShrink:
+				warn = true
+			case d.Severity == tfprotov6.DiagnosticSeverityError:
+				t.Errorf("%s: unexpected error: %s: %s", tt.name, d.Summary, d.Detail)
+			}
+		}
+		if warn != tt.warn {
+			t.Errorf("%s: size %d, actual %s: got diagnostics %v", tt.name, tt.size, tt.actual, resp.Diagnostics)
+		}
+
+		planned, err := resp.PlannedState.Unmarshal(objType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var attrs map[string]tftypes.Value
+		var size big.Float
+		if err := planned.As(&attrs); err != nil {
+			t.Fatal(err)
+		}
+		if err := attrs[KeySize].As(&size); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := size.Int64(); got != tt.want {
+			t.Errorf("%s: size %d, actual %s: got planned size %d, want %d", tt.name, tt.size, tt.actual, got, tt.want)
+		}
+	}
+}
@@ -8,8 +8,11 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -17,6 +20,13 @@ const (
 	ZoneDataSourceName = "zone"
 )
 
+type ZoneDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Desc   types.String `tfsdk:"desc"`
+	Region types.String `tfsdk:"region"`
+}
+
 var _ datasource.DataSource = &ZoneDataSource{}
 var _ datasource.DataSourceWithConfigure = &ZoneDataSource{}
 
@@ -37,11 +47,49 @@ func (d *ZoneDataSource) Configure(ctx context.Context, req datasource.Configure
 }
 
 func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	datasourceFilteredSchema(resp, ZoneDataSourceName)
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Data from a %s resource", ZoneDataSourceName),
+		Attributes: map[string]schema.Attribute{
+			KeyID: schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: DataSourceIdDescription,
+			},
+			KeyName: schema.StringAttribute{
+				MarkdownDescription: "Zone name or ID",
+				Required:            true,
+			},
+			KeyDesc: schema.StringAttribute{
+				MarkdownDescription: "Zone description",
+				Computed:            true,
+			},
+			KeyRegion: schema.StringAttribute{
+				MarkdownDescription: "ID of the region the zone belongs to",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// finds the ID of the region a zone belongs to, zones not referencing it
+func (d *ZoneDataSource) region(ctx context.Context, zoneId string) (string, error) {
+	regions, _, err := d.Data.K.RegionAPI.ListRegions(ctx).Execute()
+	if err != nil {
+		return "", err
+	}
+	for _, regionId := range regions {
+		zones, _, err := d.Data.K.RegionAPI.ListRegionZones(ctx, regionId).Execute()
+		if err != nil {
+			return "", err
+		}
+		if slices.Contains(zones, zoneId) {
+			return regionId, nil
+		}
+	}
+	return "", fmt.Errorf("%s", ErrorUnknownRegion)
 }
 
 func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data GenericDataSourceModel
+	var data ZoneDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -49,18 +97,29 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	d.Data.Mutex.Lock()
 	defer d.Data.Mutex.Unlock()
 
-	zones, _, err := d.Data.K.ZoneAPI.ListZones(ctx).Execute()
+	zoneId, err := getZoneID(ctx, d.Data, data.Name.ValueString())
 	if err != nil {
 		errorDataSourceReadGeneric(resp, err)
 		return
 	}
-	for _, rg := range zones {
-		r, _, err := d.Data.K.ZoneAPI.ReadZone(ctx, rg).Execute()
-		if err == nil && r.Name == data.Name.ValueString() {
-			data.ID = types.StringPointerValue(r.Id)
-			break
-		}
+	zone, _, err := d.Data.K.ZoneAPI.ReadZone(ctx, zoneId).Execute()
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+	regionId, err := d.region(ctx, zoneId)
+	if err != nil {
+		errorDataSourceReadGeneric(resp, err)
+		return
+	}
+
+	data.ID = types.StringPointerValue(zone.Id)
+	if zone.Description != nil {
+		data.Desc = types.StringPointerValue(zone.Description)
+	} else {
+		data.Desc = types.StringValue("")
 	}
+	data.Region = types.StringValue(regionId)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
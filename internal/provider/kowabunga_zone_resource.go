@@ -122,6 +122,7 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	data.ID = types.StringPointerValue(zone.Id)
 	zoneModelToResource(zone, data) // read back resulting object
+	r.Data.IDs.invalidate(ZoneResourceName)
 	tflog.Trace(ctx, "created zone resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -178,6 +179,7 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	r.Data.IDs.invalidate(ZoneResourceName)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -204,5 +206,6 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		errorDeleteGeneric(resp, err)
 		return
 	}
+	r.Data.IDs.invalidate(ZoneResourceName)
 	tflog.Trace(ctx, "Deleted "+data.ID.ValueString())
 }
@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"sync"
+)
+
+// objectLocks hands out per-object mutexes, serializing read-modify-write
+// updates of a shared API object (e.g. Kawaii NAT rules and VPC peerings,
+// Konvey backends, DNS records of a project or region) whatever provider's
+// parallelism setting
+type objectLocks struct {
+	mutex sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newObjectLocks() *objectLocks {
+	return &objectLocks{
+		locks: map[string]*sync.Mutex{},
+	}
+}
+
+// locks an object, returns the function to unlock it
+func (l *objectLocks) lock(kind, id string) func() {
+	l.mutex.Lock()
+	key := kind + "/" + id
+	m, ok := l.locks[key]
+	if !ok {
+		m = &sync.Mutex{}
+		l.locks[key] = m
+	}
+	l.mutex.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
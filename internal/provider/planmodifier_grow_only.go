@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+const (
+	PlanModifierGrowOnlyDescription = "Value can only grow. A value not greater than the actual one (e.g. actual size grown beyond the configured one from a larger template) plans no change."
+	PlanModifierGrowOnlyWarnShrink  = "Unsupported shrink"
+
+	planModifierGrowOnlyPrivateKeyPrefix = "actual_"
+)
+
+// privateState is the part of resource responses' private state used to
+// record actual values of grow-only attributes
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// records the actual value of a grow-only attribute, as state keeps the
+// configured one as long as the actual one is greater
+func growOnlySetActual(ctx context.Context, private privateState, attr string, actual SizeGBValue) diag.Diagnostics {
+	return private.SetKey(ctx, planModifierGrowOnlyPrivateKeyPrefix+attr, []byte(strconv.FormatInt(actual.ValueInt64(), 10)))
+}
+
+type int64GrowOnlyModifier struct{}
+
+func (m int64GrowOnlyModifier) Description(ctx context.Context) string {
+	return PlanModifierGrowOnlyDescription
+}
+
+func (m int64GrowOnlyModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m int64GrowOnlyModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+
+	// nothing to compare to on creation or destruction
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.StateValue.IsUnknown() || req.StateValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.IsNull() {
+		return
+	}
+
+	// actual value, unknown for resources created by older provider versions
+	var actual *int64
+	value, diags := req.Private.GetKey(ctx, planModifierGrowOnlyPrivateKeyPrefix+req.Path.String())
+	resp.Diagnostics.Append(diags...)
+	if len(value) > 0 {
+		v, err := strconv.ParseInt(string(value), 10, 64)
+		if err == nil {
+			actual = &v
+		}
+	}
+
+	plan, state := req.PlanValue.ValueInt64(), req.StateValue.ValueInt64()
+	if growOnlyPlan(plan, state, actual) == state {
+		if plan < state {
+			resp.Diagnostics.AddAttributeWarning(req.Path, PlanModifierGrowOnlyWarnShrink,
+				fmt.Sprintf("%s: %d < %d, kept unchanged", PlanModifierGrowOnlyDescription, plan, state))
+		}
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// returns the value to be planned out of configured, prior state and actual
+// ones: a configured value not greater than the actual one (or the state one,
+// which is never greater, if unknown) means no change, the state one being
+// kept, while a greater one grows it
+func growOnlyPlan(plan, state int64, actual *int64) int64 {
+	current := state
+	if actual != nil && *actual > current {
+		current = *actual
+	}
+	if plan <= current {
+		return state
+	}
+	return plan
+}
+
+// returns the size to be kept in state: the configured (prior) one as long
+// as the actual one is greater or equal (e.g. grown from template), the actual
+// one otherwise
+func sizeGBGrowOnlyValue(prior SizeGBValue, actual SizeGBValue) SizeGBValue {
+	if prior.IsNull() || prior.IsUnknown() {
+		return actual
+	}
+	if actual.ValueInt64() >= prior.ValueInt64() {
+		return prior
+	}
+	return actual
+}
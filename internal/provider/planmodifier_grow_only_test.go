@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestGrowOnlyPlan(t *testing.T) {
+	unknown := (*int64)(nil)
+	actual := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name   string
+		plan   int64
+		state  int64
+		actual *int64
+		want   int64
+	}{
+		{"unchanged", 10, 10, actual(10), 10},
+		{"grow", 15, 10, actual(10), 15},
+		{"shrink", 5, 10, actual(10), 10},
+		{"unchanged, grown from template", 10, 10, actual(20), 10},
+		{"grow below actual", 15, 10, actual(20), 10},
+		{"grow up to actual", 20, 10, actual(20), 10},
+		{"grow beyond actual", 25, 10, actual(20), 25},
+		{"grow, unknown actual", 15, 10, unknown, 15},
+		{"shrink, unknown actual", 5, 10, unknown, 10},
+	}
+	for _, tt := range tests {
+		if got := growOnlyPlan(tt.plan, tt.state, tt.actual); got != tt.want {
+			t.Errorf("%s: plan %d, state %d: got %d, want %d", tt.name, tt.plan, tt.state, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	PlanModifierUseStateUnlessChangedDescription = "Once set, the value of this attribute in state will not change, unless the attributes it derives from do."
+)
+
+// listUseStateForUnknownUnless keeps a computed list prior value, as
+// UseStateForUnknown does, unless one of the list attributes it's derived
+// from (e.g. instance's MAC addresses from its adapters) is planned to change
+type listUseStateForUnknownUnless struct {
+	paths []path.Path
+}
+
+func listUseStateForUnknownUnlessChanged(paths ...path.Path) planmodifier.List {
+	return listUseStateForUnknownUnless{paths: paths}
+}
+
+func (m listUseStateForUnknownUnless) Description(ctx context.Context) string {
+	return PlanModifierUseStateUnlessChangedDescription
+}
+
+func (m listUseStateForUnknownUnless) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m listUseStateForUnknownUnless) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// nothing to keep on creation, nor to override when already known
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, p := range m.paths {
+		var plan, state types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &plan)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &state)...)
+		if resp.Diagnostics.HasError() || !plan.Equal(state) {
+			return
+		}
+	}
+	resp.PlanValue = req.StateValue
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestInstanceResourcePlanMACs(t *testing.T) {
+	ctx := context.Background()
+	s := testResourceSchema(NewInstanceResource())
+
+	strings := func(values ...string) tftypes.Value {
+		elems := []tftypes.Value{}
+		for _, v := range values {
+			elems = append(elems, tftypes.NewValue(tftypes.String, v))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+	}
+	instance := func(adapters tftypes.Value) tftypes.Value {
+		return testResourceValue(s, map[string]tftypes.Value{
+			KeyAdapters: adapters,
+		})
+	}
+	unknown := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue)
+	macs := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("52:54:00:00:00:01")})
+
+	tests := []struct {
+		name     string
+		adapters tftypes.Value
+		keep     bool
+	}{
+		{"adapters unchanged", strings("a1"), true},
+		{"adapter added", strings("a1", "a2"), false},
+		{"adapter replaced", strings("a2"), false},
+		{"adapters unknown", unknown, false},
+	}
+	for _, tt := range tests {
+		req := planmodifier.ListRequest{
+			Path:        path.Root(KeyMACs),
+			ConfigValue: types.ListNull(types.StringType),
+			PlanValue:   types.ListUnknown(types.StringType),
+			StateValue:  macs,
+			Plan:        tfsdk.Plan{Schema: s, Raw: instance(tt.adapters)},
+			State:       tfsdk.State{Schema: s, Raw: instance(strings("a1"))},
+		}
+		resp := &planmodifier.ListResponse{PlanValue: req.PlanValue}
+		listUseStateForUnknownUnlessChanged(path.Root(KeyAdapters)).PlanModifyList(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", tt.name, resp.Diagnostics)
+		}
+		if kept := resp.PlanValue.Equal(macs); kept != tt.keep {
+			t.Errorf("%s: got plan %v, want state kept: %t", tt.name, resp.PlanValue, tt.keep)
+		}
+	}
+}
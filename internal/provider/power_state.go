@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	PowerStateRunning   = "running"
+	PowerStateStopped   = "stopped"
+	PowerStateRestarted = "restarted"
+
+	PowerStateDefault = PowerStateRunning
+
+	PowerStatePollInterval = 5 * time.Second
+)
+
+var powerSupportedStates = []string{
+	PowerStateRunning,
+	PowerStateStopped,
+	PowerStateRestarted,
+}
+
+// virtual machine power actions, as exposed by both kompute and instance APIs
+type powerActions struct {
+	Start    func() (*http.Response, error)
+	Stop     func() (*http.Response, error)
+	Reboot   func() (*http.Response, error)
+	Shutdown func() (*http.Response, error)
+	State    func() (*sdk.InstanceState, *http.Response, error)
+
+	Mutex           sync.Locker   // held by caller, released while waiting
+	ShutdownTimeout time.Duration // graceful shutdown timeout on stop, forced power off if 0
+}
+
+func resourcePowerStateAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("%s desired power state. Valid values are `%s`. Changing to `%s` reboots a running %s once, which is then considered as `%s` as long as it keeps running: use `%s` for further reboots. Default is `%s`", kind, strings.Join(powerSupportedStates, " | "), PowerStateRestarted, kind, PowerStateRestarted, KeyRebootTrigger, PowerStateDefault),
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(PowerStateDefault),
+		Validators: []validator.String{
+			stringvalidator.OneOf(powerSupportedStates...),
+		},
+	}
+}
+
+func resourceRebootTriggerAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Arbitrary value (e.g. a timestamp or a configuration digest), any change of which reboots a running %s", kind),
+		Optional:            true,
+	}
+}
+
+// maps hypervisor's actual state to a power state, preserving desired one
+// whenever it matches, so that drift only shows up on actual power changes
+func powerStateFromAPI(s *sdk.InstanceState, desired string) string {
+	state := PowerStateStopped
+	if s != nil && strings.EqualFold(s.State, PowerStateRunning) {
+		state = PowerStateRunning
+	}
+	if state == PowerStateRunning && desired == PowerStateRestarted {
+		return desired
+	}
+	return state
+}
+
+// reads actual power state, keeping the prior one if hypervisor can't report
+// it, so that a transient failure doesn't fail the whole refresh
+func powerStateRead(ctx context.Context, a powerActions, prior string) string {
+	s, _, err := a.State()
+	if err != nil {
+		tflog.Warn(ctx, "unable to read power state, keeping prior one: "+err.Error())
+		return prior
+	}
+	return powerStateFromAPI(s, prior)
+}
+
+// performs the power action required to move from current to desired state
+func powerStateApply(ctx context.Context, current, desired string, a powerActions) error {
+	if current == desired {
+		return nil
+	}
+
+	var err error
+	switch desired {
+	case PowerStateStopped:
+		if a.ShutdownTimeout > 0 {
+			return powerGracefulStop(ctx, a)
+		}
+		_, err = a.Stop()
+	case PowerStateRunning:
+		if current == PowerStateStopped {
+			_, err = a.Start()
+		}
+	case PowerStateRestarted:
+		if current == PowerStateStopped {
+			_, err = a.Start()
+		} else {
+			_, err = a.Reboot()
+		}
+	}
+	return err
+}
+
+// reboots a running virtual machine whenever its reboot trigger changed,
+// unless stopped or already (re)started by the power state change
+func powerRebootOnTrigger(prior, planned types.String, current, desired string, a powerActions) error {
+	if planned.IsNull() || planned.Equal(prior) {
+		return nil
+	}
+	if current == PowerStateStopped || desired == PowerStateStopped {
+		return nil
+	}
+	if current == PowerStateRunning && desired == PowerStateRestarted {
+		return nil
+	}
+	_, err := a.Reboot()
+	return err
+}
+
+// waits for a given duration, releasing caller's lock meanwhile
+func powerWait(ctx context.Context, a powerActions, d time.Duration) error {
+	a.Mutex.Unlock()
+	defer a.Mutex.Lock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// gracefully shuts a running virtual machine down (ACPI), waiting up to
+// shutdown timeout for it to power off by itself, before forcing it off
+func powerGracefulStop(ctx context.Context, a powerActions) error {
+	timeout := a.ShutdownTimeout
+	s, _, err := a.State()
+	if err != nil {
+		return err
+	}
+	if powerStateFromAPI(s, PowerStateRunning) != PowerStateRunning {
+		return nil
+	}
+
+	_, err = a.Shutdown()
+	if err == nil {
+		deadline := time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			err := powerWait(ctx, a, min(PowerStatePollInterval, time.Until(deadline)))
+			if err != nil {
+				return err
+			}
+			s, _, err := a.State()
+			if err == nil && powerStateFromAPI(s, PowerStateRunning) != PowerStateRunning {
+				return nil
+			}
+		}
+		tflog.Warn(ctx, fmt.Sprintf("graceful shutdown did not complete within %s, forcing power off", timeout))
+	} else {
+		tflog.Warn(ctx, "graceful shutdown failed, forcing power off: "+err.Error())
+	}
+
+	_, err = a.Stop()
+	return err
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fake virtual machine, powering off once shut down if it honors ACPI
+type powerTestVM struct {
+	mutex   sync.Mutex
+	running bool
+	acpi    bool
+	stopped bool // forced off
+	locked  []bool
+}
+
+func (vm *powerTestVM) actions(timeout time.Duration) powerActions {
+	return powerActions{
+		Stop: func() (*http.Response, error) {
+			vm.running = false
+			vm.stopped = true
+			return nil, nil
+		},
+		Shutdown: func() (*http.Response, error) {
+			if vm.acpi {
+				vm.running = false
+			}
+			return nil, nil
+		},
+		State: func() (*sdk.InstanceState, *http.Response, error) {
+			// records whether caller's lock is held while calling API
+			locked := !vm.mutex.TryLock()
+			if !locked {
+				vm.mutex.Unlock()
+			}
+			vm.locked = append(vm.locked, locked)
+			state := PowerStateStopped
+			if vm.running {
+				state = PowerStateRunning
+			}
+			return &sdk.InstanceState{State: state}, nil, nil
+		},
+		Mutex:           &vm.mutex,
+		ShutdownTimeout: timeout,
+	}
+}
+
+func TestPowerGracefulStop(t *testing.T) {
+	for _, acpi := range []bool{true, false} {
+		vm := &powerTestVM{running: true, acpi: acpi}
+		vm.mutex.Lock()
+		err := powerGracefulStop(context.Background(), vm.actions(10*time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vm.mutex.TryLock() {
+			t.Errorf("acpi %v: caller's lock not held anymore", acpi)
+		}
+		if vm.running || vm.stopped == acpi {
+			t.Errorf("acpi %v: got running %v, forced off %v", acpi, vm.running, vm.stopped)
+		}
+		for i, locked := range vm.locked {
+			if !locked {
+				t.Errorf("acpi %v: state #%d read without lock", acpi, i)
+			}
+		}
+	}
+}
+
+func TestPowerStateApplyStopped(t *testing.T) {
+	vm := &powerTestVM{running: true, acpi: true}
+	vm.mutex.Lock()
+	defer vm.mutex.Unlock()
+
+	// hard stop without shutdown timeout
+	err := powerStateApply(context.Background(), PowerStateRunning, PowerStateStopped, vm.actions(0))
+	if err != nil || !vm.stopped {
+		t.Errorf("expected forced power off, got %v", err)
+	}
+
+	vm = &powerTestVM{running: true, acpi: true}
+	vm.mutex.Lock()
+	defer vm.mutex.Unlock()
+	err = powerStateApply(context.Background(), PowerStateRunning, PowerStateStopped, vm.actions(50*time.Millisecond))
+	if err != nil || vm.stopped || vm.running {
+		t.Errorf("expected graceful shutdown, got %v", err)
+	}
+}
+
+func TestPowerStateRead(t *testing.T) {
+	a := powerActions{
+		State: func() (*sdk.InstanceState, *http.Response, error) {
+			return nil, nil, errors.New("hypervisor unreachable")
+		},
+	}
+	if got := powerStateRead(context.Background(), a, PowerStateStopped); got != PowerStateStopped {
+		t.Errorf("got %s, want prior state kept", got)
+	}
+}
+
+func TestPowerRebootOnTrigger(t *testing.T) {
+	tests := []struct {
+		name             string
+		prior, planned   types.String
+		current, desired string
+		reboot           bool
+	}{
+		{"unchanged", types.StringValue("1"), types.StringValue("1"), PowerStateRunning, PowerStateRunning, false},
+		{"changed", types.StringValue("1"), types.StringValue("2"), PowerStateRunning, PowerStateRunning, true},
+		{"changed, restarted", types.StringValue("1"), types.StringValue("2"), PowerStateRestarted, PowerStateRestarted, true},
+		{"set", types.StringNull(), types.StringValue("1"), PowerStateRunning, PowerStateRunning, true},
+		{"unset", types.StringValue("1"), types.StringNull(), PowerStateRunning, PowerStateRunning, false},
+		{"changed, stopping", types.StringValue("1"), types.StringValue("2"), PowerStateRunning, PowerStateStopped, false},
+		{"changed, starting", types.StringValue("1"), types.StringValue("2"), PowerStateStopped, PowerStateRunning, false},
+		{"changed, restarting", types.StringValue("1"), types.StringValue("2"), PowerStateRunning, PowerStateRestarted, false},
+	}
+	for _, tt := range tests {
+		rebooted := false
+		a := powerActions{
+			Reboot: func() (*http.Response, error) {
+				rebooted = true
+				return nil, nil
+			},
+		}
+		err := powerRebootOnTrigger(tt.prior, tt.planned, tt.current, tt.desired, a)
+		if err != nil || rebooted != tt.reboot {
+			t.Errorf("%s: got reboot %v (%v), want %v", tt.name, rebooted, err, tt.reboot)
+		}
+	}
+}
+
+func TestPowerWaitCancelled(t *testing.T) {
+	vm := &powerTestVM{}
+	a := vm.actions(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	vm.mutex.Lock()
+	err := powerWait(ctx, a, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+	if vm.mutex.TryLock() {
+		t.Error("caller's lock not held back")
+	}
+}
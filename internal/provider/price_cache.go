@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"sync"
+)
+
+// unitPrices holds monthly prices per vCPU, GB of memory and GB of storage
+type unitPrices struct {
+	CPU      float64
+	Memory   float64
+	Storage  float64
+	Currency string
+}
+
+// priceCache keeps track of unit prices (e.g. zone's kaktus nodes or storage
+// pool ones), computed once for the lifetime of the provider, so that price
+// estimates don't read all kaktus nodes on every refresh
+type priceCache struct {
+	mutex  sync.Mutex
+	prices map[string]unitPrices
+}
+
+func newPriceCache() *priceCache {
+	return &priceCache{
+		prices: map[string]unitPrices{},
+	}
+}
+
+// returns cached unit prices, computing them on first use (failures are not
+// cached, to be retried on next use)
+func (c *priceCache) get(key string, compute func() (unitPrices, error)) (unitPrices, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if p, ok := c.prices[key]; ok {
+		return p, nil
+	}
+	p, err := compute()
+	if err != nil {
+		return p, err
+	}
+	c.prices[key] = p
+	return p, nil
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPriceCache(t *testing.T) {
+	c := newPriceCache()
+	calls := 0
+	compute := func(err error) func() (unitPrices, error) {
+		return func() (unitPrices, error) {
+			calls++
+			return unitPrices{CPU: 1.5, Currency: "EUR"}, err
+		}
+	}
+
+	// failures are retried
+	if _, err := c.get("zone/a", compute(errors.New("unreachable"))); err == nil {
+		t.Fatal("expected error")
+	}
+	for range 3 {
+		p, err := c.get("zone/a", compute(nil))
+		if err != nil || p.CPU != 1.5 {
+			t.Fatalf("got %v (%v)", p, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d computations, want 2", calls)
+	}
+}
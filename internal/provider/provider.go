@@ -9,15 +9,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/http"
 	"net/url"
+	"slices"
 	"sync"
+	"time"
 
 	sdk "github.com/kowabunga-cloud/kowabunga-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -29,14 +35,40 @@ const (
 var _ provider.Provider = &KowabungaProvider{}
 
 type KowabungaProviderModel struct {
-	URI   types.String `tfsdk:"uri"`
-	Token types.String `tfsdk:"token"`
+	URI             types.String `tfsdk:"uri"`
+	Token           types.String `tfsdk:"token"`
+	Profile         types.String `tfsdk:"profile"`
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+	DebugHTTP       types.Bool   `tfsdk:"debug_http"`
+	StrictDecoding  types.Bool   `tfsdk:"strict_decoding"`
+	StrictRefs      types.Bool   `tfsdk:"strict_references"`
+	DefaultTags     types.List   `tfsdk:"default_tags"`
+	DefaultMetadata types.Map    `tfsdk:"default_metadata"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryWait       types.String `tfsdk:"retry_wait"`
+	RetryableCodes  types.List   `tfsdk:"retryable_status_codes"`
+	ParallelSafe    types.Bool   `tfsdk:"parallelism_safe"`
+	MixedCurrencies types.Bool   `tfsdk:"allow_mixed_currencies"`
+	MaxIdleConns    types.Int64  `tfsdk:"http_max_idle_conns"`
+	MaxIdleConnsPH  types.Int64  `tfsdk:"http_max_idle_conns_per_host"`
+	IdleConnTimeout types.String `tfsdk:"http_idle_conn_timeout"`
+	KeepAlive       types.String `tfsdk:"http_keep_alive"`
+	Timeout         types.String `tfsdk:"http_timeout"`
 }
 
 type KowabungaProviderData struct {
-	K     *sdk.APIClient
-	Mutex *sync.Mutex
-	Cond  *sync.Cond
+	K                    *sdk.APIClient
+	Mutex                sync.Locker // serializes API calls, no-op when parallelism is safe
+	DefaultTags          []string
+	DefaultMetadata      map[string]string
+	IDs                  *idCache
+	DnsRecords           *dnsRecordIndex
+	Locks                *objectLocks
+	Deprecations         *deprecationUsages
+	Prices               *priceCache
+	ParallelSafe         bool
+	StrictReferences     bool
+	AllowMixedCurrencies bool
 }
 
 type KowabungaProvider struct {
@@ -65,19 +97,99 @@ func (p *KowabungaProvider) Schema(ctx context.Context, req provider.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			KeyURI: schema.StringAttribute{
-				MarkdownDescription: "Kowabunga platform URI",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("Kowabunga platform URI. Can also be set through the `%s` environment variable or a credentials file profile.", CredentialsEnvURI),
+				Optional:            true,
 			},
 			KeyToken: schema.StringAttribute{
-				MarkdownDescription: "Kowabunga platform token (API key)",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("Kowabunga platform token (API key). Can also be set through the `%s` environment variable or a credentials file profile.", CredentialsEnvToken),
+				Optional:            true,
 				Sensitive:           true,
 			},
+			KeyProfile: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Named profile of the credentials file to read `%s` and `%s` from, when not otherwise set. When configured, it takes precedence over `%s` and `%s` environment variables. Can also be set through the `%s` environment variable, which doesn't. Defaults to **%s**.", KeyURI, KeyToken, CredentialsEnvURI, CredentialsEnvToken, CredentialsEnvProfile, CredentialsDefaultProfile),
+				Optional:            true,
+			},
+			KeyCredentialsFile: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Path to the INI-like credentials file, with one `[profile]` section per platform, each with `%s` and `%s` keys. Can also be set through the `%s` environment variable. Defaults to **~/%s**.", KeyURI, KeyToken, CredentialsEnvFile, CredentialsDefaultFile),
+				Optional:            true,
+			},
+			KeyDebugHTTP: schema.BoolAttribute{
+				MarkdownDescription: "Log sanitized HTTP requests and responses payloads (API key and secrets are redacted), for troubleshooting purpose only. Logs are emitted at DEBUG level (e.g. `TF_LOG_PROVIDER=debug`). Defaults to **false**.",
+				Optional:            true,
+			},
+			KeyStrictDecoding: schema.BoolAttribute{
+				MarkdownDescription: "Fail reads of objects for which Kowabunga platform returns fields unknown to the provider, which would otherwise be silently dropped or fail decoding. Such fields returned on creation or update, which can't be failed once applied, are reported as WARN level logs (and fail the next refresh). Helps detecting an outdated provider against a newer platform. Defaults to **false**.",
+				Optional:            true,
+			},
+			KeyStrictReferences: schema.BoolAttribute{
+				MarkdownDescription: "Fail resources creation when an explicitly set optional reference (e.g. Kompute or volume storage pool and template, Kylo NFS storage) can't be resolved, instead of silently falling back to platform defaults. Defaults to **false**.",
+				Optional:            true,
+			},
+			KeyAllowMixedCurrencies: schema.BoolAttribute{
+				MarkdownDescription: "Only warn, instead of failing the plan, when kaktus nodes and storage pools costs of a region are expressed in different currencies (which cost reports sum up without any conversion), e.g. to migrate a region to another currency one object at a time. Defaults to **false**.",
+				Optional:            true,
+			},
+			KeyDefaultTags: schema.ListAttribute{
+				MarkdownDescription: "List of tags to be associated with every resource supporting tags, in addition to resource's own ones. Default tags are not reported in resources state unless explicitly configured there.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			KeyDefaultMetadata: schema.MapAttribute{
+				MarkdownDescription: "List of metadatas key/value to be associated with every resource supporting metadatas. Resource's own metadatas take precedence over default ones with the same key. Default metadatas are not reported in resources state unless explicitly configured there.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			KeyParallelismSafe: schema.BoolAttribute{
+				MarkdownDescription: "Let resources operations run concurrently, with Terraform's own parallelism, instead of serializing all API calls. Speeds up large plans significantly, to be enabled only if Kowabunga platform handles concurrent requests (e.g. resources allocation) safely. Read-modify-write updates of objects shared across resources (Kawaii NAT rules and VPC peerings, Konvey backends drained on Kompute deletion, DNS records ownership checks) remain serialized per object. Defaults to **false**.",
+				Optional:            true,
+			},
+			KeyHTTPMaxIdleConns: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of idle (keep-alive) HTTP connections kept open to Kowabunga platform. Defaults to **%d**.", HTTPDefaultMaxIdleConns),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			KeyHTTPMaxIdleConnsPerHost: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of idle (keep-alive) HTTP connections kept open per API host. Raise it to prevent large refreshes from exhausting ephemeral ports against a single-endpoint platform. Defaults to **%d**.", HTTPDefaultMaxIdleConnsPerHost),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			KeyHTTPIdleConnTimeout: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Time an idle HTTP connection remains open before closing itself. Use s, m suffixes, 0s for no limit. Defaults to **%s**.", HTTPDefaultIdleConnTimeout),
+				Optional:            true,
+			},
+			KeyHTTPKeepAlive: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("TCP keep-alive probes period of API connections. Use s, m suffixes, a negative value to disable. Defaults to **%s**.", HTTPDefaultKeepAlive),
+				Optional:            true,
+			},
+			KeyHTTPTimeout: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Overall time limit of a single API call, retry attempts included. Use s, m suffixes, 0s for no limit. Defaults to **%s**.", HTTPDefaultTimeout),
+				Optional:            true,
+			},
+			KeyMaxRetries: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of times an API request failing with a transient error (network failure or retryable HTTP status code) is retried, with exponential backoff. Object creation requests are only retried when rate-limited. Use 0 to disable retries. Defaults to **%d**.", RetryHTTPDefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			KeyRetryWait: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Initial time to wait for before retrying a failed API request, doubled on each attempt (unless server requests otherwise). Use s, ms suffixes. Defaults to **%s**.", RetryHTTPDefaultWait),
+				Optional:            true,
+			},
+			KeyRetryableStatusCodes: schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("HTTP status codes API requests are retried on. Defaults to **%s**.", retryHTTPDefaultStatusCodesString()),
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
 		},
 	}
 }
 
-func newKowabungaClient(uri, token string) (*sdk.APIClient, error) {
+func newKowabungaClient(uri, token string, debug, strict bool, retry RetryConfig, tuning HTTPConfig) (*sdk.APIClient, error) {
 	if uri == "" || token == "" {
 		return nil, fmt.Errorf("the Kowabunga provider needs proper initialization parameters")
 	}
@@ -90,7 +202,32 @@ func newKowabungaClient(uri, token string) (*sdk.APIClient, error) {
 	cfg := sdk.NewConfiguration()
 	cfg.Host = u.Host
 	cfg.Scheme = u.Scheme
-	cfg.Debug = true
+	var transport http.RoundTripper = &telemetryHTTPTransport{
+		transport: newTunedHTTPTransport(tuning),
+		telemetry: apiTelemetry,
+	}
+	if debug {
+		transport = &debugHTTPTransport{
+			transport: transport,
+		}
+	}
+	if retry.MaxRetries > 0 {
+		transport = &retryHTTPTransport{
+			transport: transport,
+			config:    retry,
+		}
+	}
+	// outermost, not to retry failed reads
+	if strict {
+		transport = &strictHTTPTransport{
+			transport: transport,
+			reported:  map[string]bool{},
+		}
+	}
+	cfg.HTTPClient = &http.Client{
+		Transport: transport,
+		Timeout:   tuning.Timeout,
+	}
 	cfg.AddDefaultHeader("X-API-Key", token)
 
 	return sdk.NewAPIClient(cfg), nil
@@ -104,22 +241,65 @@ func (p *KowabungaProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	if data.URI.IsNull() || data.Token.IsNull() {
+	if data.URI.IsUnknown() || data.Token.IsUnknown() {
 		resp.Diagnostics.AddError("Unknown Value", "An attribute value is not yet known")
 		return
 	}
 
-	k, err := newKowabungaClient(data.URI.ValueString(), data.Token.ValueString())
+	uri, token, err := providerCredentials(data.URI.ValueString(), data.Token.ValueString(), data.Profile.ValueString(), data.CredentialsFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid credentials file", err.Error())
+		return
+	}
+	if uri == "" || token == "" {
+		resp.Diagnostics.AddError("Missing credentials", fmt.Sprintf("Kowabunga platform %s and %s must be set, either in provider's configuration, through %s and %s environment variables, or from a credentials file profile", KeyURI, KeyToken, CredentialsEnvURI, CredentialsEnvToken))
+		return
+	}
+
+	retry, err := providerRetryConfig(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid retry configuration", err.Error())
+		return
+	}
+
+	tuning, err := providerHTTPConfig(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP configuration", err.Error())
+		return
+	}
+
+	k, err := newKowabungaClient(uri, token, data.DebugHTTP.ValueBool(), data.StrictDecoding.ValueBool(), retry, tuning)
 	if err != nil {
 		resp.Diagnostics.AddError("No Kowabunga client", err.Error())
 		return
 	}
 
+	tags := []string{}
+	resp.Diagnostics.Append(data.DefaultTags.ElementsAs(ctx, &tags, false)...)
+	metadata := map[string]string{}
+	resp.Diagnostics.Append(data.DefaultMetadata.ElementsAs(ctx, &metadata, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var mut sync.Mutex
+	var lock sync.Locker = &mut
+	if data.ParallelSafe.ValueBool() {
+		lock = noopLocker{}
+	}
 	var d = KowabungaProviderData{
-		K:     k,
-		Mutex: &mut,
-		Cond:  sync.NewCond(&mut),
+		K:                    k,
+		Mutex:                lock,
+		DefaultTags:          tags,
+		DefaultMetadata:      metadata,
+		IDs:                  newIDCache(IDCacheDefaultTTL),
+		DnsRecords:           newDnsRecordIndex(),
+		Locks:                newObjectLocks(),
+		Deprecations:         newDeprecationUsages(),
+		Prices:               newPriceCache(),
+		StrictReferences:     data.StrictRefs.ValueBool(),
+		ParallelSafe:         data.ParallelSafe.ValueBool(),
+		AllowMixedCurrencies: data.MixedCurrencies.ValueBool(),
 	}
 
 	p.Data = &d
@@ -127,15 +307,140 @@ func (p *KowabungaProvider) Configure(ctx context.Context, req provider.Configur
 	resp.ResourceData = &d
 }
 
+// noopLocker lets resources operations run concurrently, shared objects
+// read-modify-write updates being serialized by per-object locks instead
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+// returns API client connection pool and timeouts from provider's configuration
+func providerHTTPConfig(data *KowabungaProviderModel) (HTTPConfig, error) {
+	cfg := HTTPConfig{
+		MaxIdleConns:        HTTPDefaultMaxIdleConns,
+		MaxIdleConnsPerHost: HTTPDefaultMaxIdleConnsPerHost,
+	}
+	if !data.MaxIdleConns.IsNull() {
+		cfg.MaxIdleConns = int(data.MaxIdleConns.ValueInt64())
+	}
+	if !data.MaxIdleConnsPH.IsNull() {
+		cfg.MaxIdleConnsPerHost = int(data.MaxIdleConnsPH.ValueInt64())
+	}
+
+	durations := []struct {
+		value types.String
+		def   string
+		dst   *time.Duration
+	}{
+		{data.IdleConnTimeout, HTTPDefaultIdleConnTimeout, &cfg.IdleConnTimeout},
+		{data.KeepAlive, HTTPDefaultKeepAlive, &cfg.KeepAlive},
+		{data.Timeout, HTTPDefaultTimeout, &cfg.Timeout},
+	}
+	for _, d := range durations {
+		v := d.def
+		if !d.value.IsNull() {
+			v = d.value.ValueString()
+		}
+		var err error
+		*d.dst, err = time.ParseDuration(v)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// returns API requests retry policy from provider's configuration
+func providerRetryConfig(ctx context.Context, data *KowabungaProviderModel) (RetryConfig, error) {
+	cfg := RetryConfig{
+		MaxRetries:  RetryHTTPDefaultMaxRetries,
+		StatusCodes: retryHTTPDefaultStatusCodes,
+	}
+	if !data.MaxRetries.IsNull() {
+		cfg.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	wait := RetryHTTPDefaultWait
+	if !data.RetryWait.IsNull() {
+		wait = data.RetryWait.ValueString()
+	}
+	var err error
+	cfg.Wait, err = time.ParseDuration(wait)
+	if err != nil {
+		return cfg, err
+	}
+
+	if !data.RetryableCodes.IsNull() {
+		cfg.StatusCodes = []int64{}
+		diags := data.RetryableCodes.ElementsAs(ctx, &cfg.StatusCodes, false)
+		if diags.HasError() {
+			return cfg, fmt.Errorf("invalid %s", KeyRetryableStatusCodes)
+		}
+	}
+
+	return cfg, nil
+}
+
+// returns resource's tags, augmented with provider's default ones
+func (d *KowabungaProviderData) withDefaultTags(tags []string) []string {
+	res := slices.Clone(tags)
+	for _, t := range d.DefaultTags {
+		if !slices.Contains(res, t) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// returns actual tags, stripped from provider's default ones not explicitly
+// configured on resource, so that they never show up as a diff
+func (d *KowabungaProviderData) withoutDefaultTags(actual, configured []string) []string {
+	res := []string{}
+	for _, t := range actual {
+		if slices.Contains(d.DefaultTags, t) && !slices.Contains(configured, t) {
+			continue
+		}
+		res = append(res, t)
+	}
+	return res
+}
+
+// returns resource's metadatas, augmented with provider's default ones
+func (d *KowabungaProviderData) withDefaultMetadata(metadata map[string]string) map[string]string {
+	res := map[string]string{}
+	maps.Copy(res, d.DefaultMetadata)
+	maps.Copy(res, metadata)
+	return res
+}
+
+// returns actual metadatas, stripped from provider's default ones not
+// explicitly configured on resource, so that they never show up as a diff
+func (d *KowabungaProviderData) withoutDefaultMetadata(actual, configured map[string]string) map[string]string {
+	res := map[string]string{}
+	for k, v := range actual {
+		if def, ok := d.DefaultMetadata[k]; ok && def == v {
+			if _, ok := configured[k]; !ok {
+				continue
+			}
+		}
+		res[k] = v
+	}
+	return res
+}
+
 func (p *KowabungaProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAdapterResource,
 		NewAgentResource,
 		NewDnsRecordResource,
+		NewDnsRecordSetResource,
 		NewInstanceResource,
 		NewKaktusResource,
 		NewKawaiiIPsecResource,
+		NewKawaiiNatRuleResource,
 		NewKawaiiResource,
+		NewKawaiiVpcPeeringResource,
 		NewKiwiResource,
 		NewKomputeResource,
 		NewKonveyResource,
@@ -156,12 +461,24 @@ func (p *KowabungaProvider) Resources(ctx context.Context) []func() resource.Res
 
 func (p *KowabungaProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAgentDataSource,
+		NewDnsRecordDataSource,
+		NewDnsRecordsDataSource,
+		NewEnumsDataSource,
+		NewInventoryDataSource,
+		NewKaktusDataSource,
+		NewKawaiiIPsecDataSource,
+		NewKawaiiReadyDataSource,
 		NewRegionDataSource,
 		NewRegionsDataSource,
+		NewStoragePoolDataSource,
 		NewSubnetDataSource,
 		NewSubnetsDataSource,
 		NewTeamDataSource,
 		NewTeamsDataSource,
+		NewTemplateDataSource,
+		NewUserDataSource,
+		NewVNetSubnetsDataSource,
 		NewZoneDataSource,
 		NewZonesDataSource,
 	}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// returns a resource's schema
+func testResourceSchema(r resource.Resource) schema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// returns a resource object value from some attributes values, all other
+// attributes being null
+func testResourceValue(s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	objType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, v := range values {
+		attrs[name] = v
+	}
+	return tftypes.NewValue(objType, attrs)
+}
+
+// returns an API client to a fake Kowabunga server
+func testAPIClient(t *testing.T, handler http.HandlerFunc) *sdk.APIClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	k, err := newKowabungaClient(srv.URL, "token", false, false, RetryConfig{}, HTTPConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
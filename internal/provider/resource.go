@@ -8,32 +8,47 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	KeyAccessType                 = "access_type"
+	KeyActiveBackendSet           = "active_backend_set"
 	KeyAdapters                   = "adapters"
 	KeyAddress                    = "address"
 	KeyAddresses                  = "addresses"
 	KeyAgents                     = "agents"
+	KeyAllowMixedCurrencies       = "allow_mixed_currencies"
+	KeyAgentTypes                 = "agent_types"
+	KeyAnsibleInventory           = "ansible_inventory"
 	KeyApp                        = "app"
 	KeyApplication                = "application"
 	KeyAssign                     = "assign"
 	KeyBackendIPs                 = "backend_ips"
 	KeyBackendPort                = "backend_port"
 	KeyBackends                   = "backends"
+	KeyBackendSets                = "backend_sets"
+	KeyBackendVMs                 = "backend_vms"
 	KeyBootstrapPubkey            = "bootstrap_pubkey"
 	KeyBootstrapUser              = "bootstrap_user"
 	KeyBot                        = "bot"
@@ -41,12 +56,17 @@ const (
 	KeyCpuOvercommit              = "cpu_overcommit"
 	KeyCpuPrice                   = "cpu_price"
 	KeyCurrency                   = "currency"
+	KeyCredentialsFile            = "credentials_file"
+	KeyDebugHTTP                  = "debug_http"
 	KeyDefault                    = "default"
+	KeyDefaultMetadata            = "default_metadata"
+	KeyDefaultTags                = "default_tags"
 	KeyDesc                       = "desc"
 	KeyDestination                = "destination"
 	KeyDisk                       = "disk"
 	KeyDNS                        = "dns"
 	KeyDomain                     = "domain"
+	KeyDrainTimeout               = "drain_timeout"
 	KeyEgressPolicy               = "egress_policy"
 	KeyEgressRules                = "egress_rules"
 	KeyEmail                      = "email"
@@ -54,17 +74,29 @@ const (
 	KeyEndpoints                  = "endpoints"
 	KeyExtraDisk                  = "extra_disk"
 	KeyFailover                   = "failover"
+	KeyFirewallPolicies           = "firewall_policies"
 	KeyFirst                      = "first"
 	KeyFS                         = "fs"
 	KeyGateway                    = "gateway"
 	KeyGwPool                     = "gw_pool"
+	KeyHTTPIdleConnTimeout        = "http_idle_conn_timeout"
+	KeyHTTPKeepAlive              = "http_keep_alive"
+	KeyHTTPMaxIdleConns           = "http_max_idle_conns"
+	KeyHTTPMaxIdleConnsPerHost    = "http_max_idle_conns_per_host"
+	KeyHTTPTimeout                = "http_timeout"
 	KeyID                         = "id"
 	KeyIngressRules               = "ingress_rules"
+	KeyInstances                  = "instances"
+	KeyInstancesCount             = "instances_count"
 	KeyInterface                  = "interface"
 	KeyIP                         = "ip"
 	KeyIPsecConnections           = "ipsec_connections"
+	KeyIPsecDHGroups              = "ipsec_dh_groups"
 	KeyIPsecDpdAction             = "dpd_action"
+	KeyIPsecDpdActions            = "ipsec_dpd_actions"
 	KeyIPsecDpdTimeout            = "dpd_timeout"
+	KeyIPsecEncryptionAlgorithms  = "ipsec_encryption_algorithms"
+	KeyIPsecIntegrityAlgorithms   = "ipsec_integrity_algorithms"
 	KeyIPsecP1DHGroupNumber       = "phase1_dh_group_number"
 	KeyIPsecP1EncryptionAlgorithm = "phase1_encryption_algorithm"
 	KeyIPsecP1IntegrityAlgorithm  = "phase1_integrity_algorithm"
@@ -75,69 +107,104 @@ const (
 	KeyIPsecP2Lifetime            = "phase2_lifetime"
 	KeyIPsecRekeyTime             = "rekey"
 	KeyIPsecStartAction           = "start_action"
+	KeyIPsecStartActions          = "ipsec_start_actions"
 	KeyKawaii                     = "kawaii"
+	KeyKyloAccessTypes            = "kylo_access_types"
+	KeyKyloProtocols              = "kylo_protocols"
 	KeyLast                       = "last"
+	KeyLiveCheck                  = "live_check"
 	KeyMAC                        = "hwaddress"
 	KeyMaxInstances               = "max_instances"
+	KeyMaxRetries                 = "max_retries"
 	KeyMaxMemory                  = "max_memory"
 	KeyMaxStorage                 = "max_storage"
 	KeyMaxVCPUs                   = "max_vcpus"
+	KeyMACs                       = "macs"
 	KeyMemory                     = "mem"
 	KeyMemoryOvercommit           = "memory_overcommit"
 	KeyMemoryPrice                = "memory_price"
+	KeyMemoryUsed                 = "mem_used"
 	KeyMetadata                   = "metadata"
+	KeyMetadataAll                = "metadata_all"
 	KeyName                       = "name"
 	KeyNatRules                   = "nat_rules"
 	KeyNetmaskBitSize             = "netmask_bitsize"
 	KeyNetmask                    = "netmask"
 	KeyNetworkConfig              = "netcfg"
+	KeyNetworkProtocols           = "network_protocols"
 	KeyNfs                        = "nfs"
 	KeyNotifications              = "notifications"
 	KeyNotify                     = "notify"
 	KeyOS                         = "os"
 	KeyOwner                      = "owner"
+	KeyParallelismSafe            = "parallelism_safe"
 	KeyPolicy                     = "policy"
+	KeyPreventRegionRemoval       = "prevent_region_removal"
 	KeyPool                       = "pool"
 	KeyPort                       = "port"
+	KeyPortlessProtocols          = "portless_protocols"
 	KeyPorts                      = "ports"
+	KeyPowerStates                = "power_states"
 	KeyPreSharedKey               = "pre_shared_key"
+	KeyPreSharedKeyWO             = "pre_shared_key_wo"
+	KeyPreSharedKeyWOVersion      = "pre_shared_key_wo_version"
 	KeyPrice                      = "price"
+	KeyPriceEstimate              = "price_estimate"
 	KeyPrivateIP                  = "private_ip"
 	KeyPrivateIPs                 = "private_ips"
 	KeyPrivate                    = "private"
 	KeyPrivateSubnets             = "private_subnets"
+	KeyPrefix                     = "prefix"
+	KeyProfile                    = "profile"
 	KeyProject                    = "project"
 	KeyProtocol                   = "protocol"
 	KeyProtocols                  = "protocols"
 	KeyPublicIP                   = "public_ip"
 	KeyPublicIPs                  = "public_ips"
 	KeyPublic                     = "public"
+	KeyRebootTrigger              = "reboot_trigger"
+	KeyRecordIDs                  = "record_ids"
+	KeyRecords                    = "records"
 	KeyRegion                     = "region"
 	KeyRegions                    = "regions"
 	KeyRemotePeer                 = "remote_peer"
 	KeyRemoteSubnet               = "remote_subnet"
 	KeyReserved                   = "reserved"
 	KeyResizable                  = "resizable"
+	KeyRetryableStatusCodes       = "retryable_status_codes"
+	KeyRetryWait                  = "retry_wait"
 	KeyRole                       = "role"
 	KeyRootPassword               = "root_password"
+	KeyRootPasswordWO             = "root_password_wo"
+	KeyRootPasswordWOVersion      = "root_password_wo_version"
 	KeyRoutes                     = "routes"
 	KeySecret                     = "secret"
+	KeyShutdownTimeout            = "shutdown_timeout"
 	KeySize                       = "size"
 	KeySource                     = "source"
+	KeyState                      = "state"
+	KeyStrictDecoding             = "strict_decoding"
+	KeyStrictReferences           = "strict_references"
 	KeySubnetSize                 = "subnet_size"
 	KeySubnet                     = "subnet"
+	KeySubnets                    = "subnets"
 	KeyTags                       = "tags"
+	KeyTagsAll                    = "tags_all"
 	KeyTeams                      = "teams"
 	KeyTemplate                   = "template"
+	KeyTemplateOS                 = "template_os"
 	KeyTimeouts                   = "timeouts"
 	KeyToken                      = "token"
 	KeyType                       = "type"
 	KeyURI                        = "uri"
+	KeyUserRoles                  = "user_roles"
 	KeyUsers                      = "users"
 	KeyVCPUs                      = "vcpus"
+	KeyVCPUsUsed                  = "vcpus_used"
 	KeyVLAN                       = "vlan"
 	KeyVNet                       = "vnet"
 	KeyVolumes                    = "volumes"
+	KeyVolumeTypes                = "volume_types"
 	KeyVpcPeerings                = "vpc_peerings"
 	KeyVRIDs                      = "vrids"
 	KeyZone                       = "zone"
@@ -148,6 +215,10 @@ const (
 	HelperGbToBytes = 1073741824
 )
 
+const (
+	PrivateKeyCreationPending = "creation_pending"
+)
+
 const (
 	DefaultCreateTimeout = 30 * time.Minute // large enough for template upload
 	DefaultDeleteTimeout = 5 * time.Minute
@@ -161,20 +232,29 @@ const (
 	ErrorExpectedProviderData = "Expected *KowabungaProviderData, got: %T."
 	ErrorUnknownKaktus        = "Unknown kaktus node"
 	ErrorUnknownKawaii        = "Unknown kawaii instance"
+	ErrorUnknownKawaiiIPsec   = "Unknown kawaii IPsec connection"
 	ErrorUnknownNfs           = "Unknown NFS storage"
 	ErrorUnknownProject       = "Unknown project"
 	ErrorUnknownRegion        = "Unknown region"
 	ErrorUnknownPool          = "Unknown storage pool"
 	ErrorUnknownSubnet        = "Unknown subnet"
+	ErrorUnknownTeam          = "Unknown team"
 	ErrorUnknownVNet          = "Unknown virtual network"
 	ErrorUnknownTemplate      = "Unknown volume template"
 	ErrorUnknownZone          = "Unknown zone"
+	ErrorQuotaExceeded        = "Project quota exceeded"
+	ErrorCurrencyMismatch     = "Mixed currencies"
+	ErrorImport               = "Invalid import identifier"
+	ErrorImportFormat         = "expected a %s/ID composite identifier, got: %s"
+	ErrorTimeoutTooShort      = "Timeout too short"
 )
 
 const (
 	ResourceIdDescription   = "Resource object internal identifier"
 	ResourceNameDescription = "Resource name"
 	ResourceDescDescription = "Resource extended description"
+
+	ResourcePortRangesDescription = "Ranges are accepted. Format is a-b,c-d (e.g. 443; 22,80,443; 80,443,3000-3005)."
 )
 
 type ResourceBaseModel struct {
@@ -193,6 +273,33 @@ func errorCreateGeneric(resp *resource.CreateResponse, err error) {
 	resp.Diagnostics.AddError(ErrorGeneric, err.Error())
 }
 
+// persists a freshly created object into state before any extra
+// post-creation call (default flag, credentials ...), along with a private
+// state breadcrumb. Should the apply be interrupted or an extra call fail,
+// Terraform keeps track of (and taints) the object instead of creating a
+// duplicate one on next run. Resources with no extra call don't need it.
+func resourceCreatePending(ctx context.Context, resp *resource.CreateResponse, data any) bool {
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, PrivateKeyCreationPending, []byte(`true`))...)
+	return !resp.Diagnostics.HasError()
+}
+
+// clears private state breadcrumb, once all post-creation calls succeeded
+func resourceCreateCompleted(ctx context.Context, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, PrivateKeyCreationPending, nil)...)
+}
+
+// reports objects whose creation never completed, from private state breadcrumb
+func resourceCreationPending(ctx context.Context, req resource.ReadRequest, id string) {
+	pending, diags := req.Private.GetKey(ctx, PrivateKeyCreationPending)
+	if diags.HasError() || pending == nil {
+		return
+	}
+	tflog.Warn(ctx, "resource creation did not complete, post-creation steps may be missing", map[string]any{
+		"id": id,
+	})
+}
+
 func errorReadGeneric(resp *resource.ReadResponse, err error) {
 	resp.Diagnostics.AddError(ErrorGeneric, err.Error())
 }
@@ -205,6 +312,38 @@ func errorDeleteGeneric(resp *resource.DeleteResponse, err error) {
 	resp.Diagnostics.AddError(ErrorGeneric, err.Error())
 }
 
+// returns whether an API call failed because requested object doesn't exist
+func errorIsNotFound(httpResp *http.Response, err error) bool {
+	return err != nil && httpResp != nil && httpResp.StatusCode == http.StatusNotFound
+}
+
+// checks that a wait (expressed in seconds, e.g. connections draining) fits
+// within resource's timeout for a given operation (i.e. delete or update)
+func resourceValidateWait(ctx context.Context, t timeouts.Value, op string, wait types.Int64, key string, diags *diag.Diagnostics) {
+	if t.IsUnknown() || wait.IsUnknown() || wait.IsNull() {
+		return
+	}
+	if v, ok := t.Attributes()[op]; ok && v.IsUnknown() {
+		return
+	}
+
+	var timeout time.Duration
+	var d diag.Diagnostics
+	switch op {
+	case "update":
+		timeout, d = t.Update(ctx, DefaultUpdateTimeout)
+	default:
+		timeout, d = t.Delete(ctx, DefaultDeleteTimeout)
+	}
+	diags.Append(d...)
+	if d.HasError() {
+		return
+	}
+	if time.Duration(wait.ValueInt64())*time.Second >= timeout {
+		diags.AddAttributeError(path.Root(key), ErrorTimeoutTooShort, fmt.Sprintf("%s of %ds must be lower than %s timeout (%s), raise timeouts.%s accordingly", key, wait.ValueInt64(), op, timeout, op))
+	}
+}
+
 func resourceAttributes(ctx *context.Context) map[string]schema.Attribute {
 	defaultAttr := map[string]schema.Attribute{
 		KeyName: schema.StringAttribute{
@@ -248,6 +387,84 @@ func resourceAttributesWithoutName(ctx *context.Context) map[string]schema.Attri
 	}
 }
 
+// returns a network address (IP or CIDR) attribute, e.g. a firewall rule source or destination
+func resourceNetworkAddressAttribute(desc, def string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("%s (defaults to %s).", desc, def),
+		CustomType:          NetworkAddressType{},
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(def),
+		Validators: []validator.String{
+			&stringNetworkAddressValidator{},
+		},
+	}
+}
+
+// returns a transport layer protocol attribute
+func resourceNetworkProtocolAttribute(desc, def string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("The transport layer protocol %s (defaults to '%s').", desc, def),
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(def),
+		Validators: []validator.String{
+			&stringNetworkProtocolValidator{},
+		},
+	}
+}
+
+// returns a firewall rule protocol attribute, supporting portless protocols
+func resourceNetworkRuleProtocolAttribute(desc, def string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("The protocol %s (defaults to '%s'). One of 'tcp', 'udp' or portless VPN and tunneling 'esp', 'gre' and 'ah' ones.", desc, def),
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(def),
+		Validators: []validator.String{
+			&stringNetworkProtocolValidator{portless: true},
+		},
+	}
+}
+
+// returns a firewall rule list of ports (or ranges of ports) attribute,
+// to be left empty for portless protocols
+func resourceNetworkRulePortRangesAttribute(desc string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("The port (or list of ports) %s. %s Required for 'tcp' and 'udp' protocols, must be left empty otherwise.", desc, ResourcePortRangesDescription),
+		CustomType:          PortRangesType{},
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(""),
+		Validators: []validator.String{
+			&stringNetworkProtocolPortsValidator{},
+		},
+	}
+}
+
+// returns a list of ports (or ranges of ports) attribute
+func resourceNetworkPortRangesAttribute(desc string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("The port (or list of ports) %s. %s", desc, ResourcePortRangesDescription),
+		CustomType:          PortRangesType{},
+		Required:            true,
+		Validators: []validator.String{
+			&stringNetworkPortRangesValidator{},
+		},
+	}
+}
+
+// returns a single network port attribute
+func resourceNetworkPortAttribute(desc string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: desc,
+		Required:            true,
+		Validators: []validator.Int64{
+			&intNetworkPortValidator{},
+		},
+	}
+}
+
 func resourceMetadata(req resource.MetadataRequest, resp *resource.MetadataResponse, name string) {
 	resp.TypeName = req.ProviderTypeName + "_" + name
 }
@@ -256,6 +473,48 @@ func resourceImportState(ctx context.Context, req resource.ImportStateRequest, r
 	resource.ImportStatePassthroughID(ctx, path.Root(KeyID), req, resp)
 }
 
+// imports a child-scoped resource, only reachable through its parent, from
+// a parent/ID composite identifier (parent being either a name or an ID)
+func resourceImportStateWithParent(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, parentKey string) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(ErrorImport, fmt.Sprintf(ErrorImportFormat, parentKey, req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(parentKey), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(KeyID), parts[1])...)
+}
+
+// requires resource replacement whenever a parent reference (name or ID)
+// changes to another object. References are resolved, so that e.g. a resource
+// imported with parent's ID but configured with its name is left unchanged.
+func resourcePlanParentReplace(ctx context.Context, data *KowabungaProviderData, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, key string, resolve func(context.Context, *KowabungaProviderData, string) (string, error)) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var prior, planned types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(key), &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(key), &planned)...)
+	if resp.Diagnostics.HasError() || planned.Equal(prior) {
+		return
+	}
+
+	if data != nil && !planned.IsUnknown() {
+		data.Mutex.Lock()
+		defer data.Mutex.Unlock()
+
+		priorId, err := resolve(ctx, data, prior.ValueString())
+		if err == nil {
+			plannedId, err := resolve(ctx, data, planned.ValueString())
+			if err == nil && plannedId == priorId {
+				return
+			}
+		}
+	}
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root(key))
+}
+
 func resourceConfigure(req resource.ConfigureRequest, resp *resource.ConfigureResponse) *KowabungaProviderData {
 	if req.ProviderData == nil {
 		return nil
@@ -271,9 +530,14 @@ func resourceConfigure(req resource.ConfigureRequest, resp *resource.ConfigureRe
 }
 
 func getRegionID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(RegionResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper region ID
 	region, _, err := data.K.RegionAPI.ReadRegion(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(RegionResourceName, id, *region.Id)
 		return *region.Id, nil
 	}
 
@@ -282,7 +546,11 @@ func getRegionID(ctx context.Context, data *KowabungaProviderData, id string) (s
 	if err == nil {
 		for _, rg := range regions {
 			r, _, err := data.K.RegionAPI.ReadRegion(ctx, rg).Execute()
-			if err == nil && r.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(RegionResourceName, r.Name, *r.Id)
+			if r.Name == id {
 				return *r.Id, nil
 			}
 		}
@@ -292,9 +560,14 @@ func getRegionID(ctx context.Context, data *KowabungaProviderData, id string) (s
 }
 
 func getZoneID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(ZoneResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper zone ID
 	zone, _, err := data.K.ZoneAPI.ReadZone(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(ZoneResourceName, id, *zone.Id)
 		return *zone.Id, nil
 	}
 
@@ -303,7 +576,11 @@ func getZoneID(ctx context.Context, data *KowabungaProviderData, id string) (str
 	if err == nil {
 		for _, zn := range zones {
 			z, _, err := data.K.ZoneAPI.ReadZone(ctx, zn).Execute()
-			if err == nil && z.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(ZoneResourceName, z.Name, *z.Id)
+			if z.Name == id {
 				return *z.Id, nil
 			}
 		}
@@ -313,9 +590,14 @@ func getZoneID(ctx context.Context, data *KowabungaProviderData, id string) (str
 }
 
 func getVNetID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(VNetResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper virtual network ID
 	vnet, _, err := data.K.VnetAPI.ReadVNet(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(VNetResourceName, id, *vnet.Id)
 		return *vnet.Id, nil
 	}
 
@@ -324,7 +606,11 @@ func getVNetID(ctx context.Context, data *KowabungaProviderData, id string) (str
 	if err == nil {
 		for _, vn := range vnets {
 			v, _, err := data.K.VnetAPI.ReadVNet(ctx, vn).Execute()
-			if err == nil && v.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(VNetResourceName, v.Name, *v.Id)
+			if v.Name == id {
 				return *v.Id, nil
 			}
 		}
@@ -334,9 +620,14 @@ func getVNetID(ctx context.Context, data *KowabungaProviderData, id string) (str
 }
 
 func getSubnetID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(SubnetResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper subnet ID
 	subnet, _, err := data.K.SubnetAPI.ReadSubnet(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(SubnetResourceName, id, *subnet.Id)
 		return *subnet.Id, nil
 	}
 
@@ -345,7 +636,11 @@ func getSubnetID(ctx context.Context, data *KowabungaProviderData, id string) (s
 	if err == nil {
 		for _, sn := range subnets {
 			s, _, err := data.K.SubnetAPI.ReadSubnet(ctx, sn).Execute()
-			if err == nil && s.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(SubnetResourceName, s.Name, *s.Id)
+			if s.Name == id {
 				return *s.Id, nil
 			}
 		}
@@ -355,9 +650,14 @@ func getSubnetID(ctx context.Context, data *KowabungaProviderData, id string) (s
 }
 
 func getProjectID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(ProjectResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper project ID
 	project, _, err := data.K.ProjectAPI.ReadProject(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(ProjectResourceName, id, *project.Id)
 		return *project.Id, nil
 	}
 
@@ -366,7 +666,11 @@ func getProjectID(ctx context.Context, data *KowabungaProviderData, id string) (
 	if err == nil {
 		for _, pn := range projects {
 			prj, _, err := data.K.ProjectAPI.ReadProject(ctx, pn).Execute()
-			if err == nil && prj.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(ProjectResourceName, prj.Name, *prj.Id)
+			if prj.Name == id {
 				return *prj.Id, nil
 			}
 		}
@@ -375,10 +679,55 @@ func getProjectID(ctx context.Context, data *KowabungaProviderData, id string) (
 	return "", fmt.Errorf("%s", ErrorUnknownProject)
 }
 
+func getTeamID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(TeamResourceName, id); ok {
+		return cached, nil
+	}
+
+	// let's suppose param is a proper team ID
+	team, _, err := data.K.TeamAPI.ReadTeam(ctx, id).Execute()
+	if err == nil {
+		data.IDs.set(TeamResourceName, id, *team.Id)
+		return *team.Id, nil
+	}
+
+	// fall back, it may be a team name then, finds its associated ID
+	teams, _, err := data.K.TeamAPI.ListTeams(ctx).Execute()
+	if err == nil {
+		for _, tn := range teams {
+			tm, _, err := data.K.TeamAPI.ReadTeam(ctx, tn).Execute()
+			if err != nil {
+				continue
+			}
+			data.IDs.set(TeamResourceName, tm.Name, *tm.Id)
+			if tm.Name == id {
+				return *tm.Id, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%s", ErrorUnknownTeam)
+}
+
+// returns an error for an explicitly set optional reference which can't be
+// resolved if provider is configured to, Kowabunga falling back to defaults
+// otherwise
+func optionalReferenceError(data *KowabungaProviderData, ref string, err error) error {
+	if err == nil || ref == "" || !data.StrictReferences {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", err, ref)
+}
+
 func getPoolID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(StoragePoolResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper pool ID
 	pool, _, err := data.K.PoolAPI.ReadStoragePool(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(StoragePoolResourceName, id, *pool.Id)
 		return *pool.Id, nil
 	}
 
@@ -387,7 +736,11 @@ func getPoolID(ctx context.Context, data *KowabungaProviderData, id string) (str
 	if err == nil {
 		for _, pn := range pools {
 			pl, _, err := data.K.PoolAPI.ReadStoragePool(ctx, pn).Execute()
-			if err == nil && pl.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(StoragePoolResourceName, pl.Name, *pl.Id)
+			if pl.Name == id {
 				return *pl.Id, nil
 			}
 		}
@@ -397,9 +750,14 @@ func getPoolID(ctx context.Context, data *KowabungaProviderData, id string) (str
 }
 
 func getNfsID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(StorageNfsResourceName, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper NFS storage ID
 	nfs, _, err := data.K.NfsAPI.ReadStorageNFS(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(StorageNfsResourceName, id, *nfs.Id)
 		return *nfs.Id, nil
 	}
 
@@ -408,7 +766,11 @@ func getNfsID(ctx context.Context, data *KowabungaProviderData, id string) (stri
 	if err == nil {
 		for _, s := range storages {
 			ns, _, err := data.K.NfsAPI.ReadStorageNFS(ctx, s).Execute()
-			if err == nil && ns.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(StorageNfsResourceName, ns.Name, *ns.Id)
+			if ns.Name == id {
 				return *ns.Id, nil
 			}
 		}
@@ -418,9 +780,15 @@ func getNfsID(ctx context.Context, data *KowabungaProviderData, id string) (stri
 }
 
 func getTemplateID(ctx context.Context, data *KowabungaProviderData, id, poolId string) (string, error) {
+	kind := TemplateResourceName + "/" + poolId
+	if cached, ok := data.IDs.get(kind, id); ok {
+		return cached, nil
+	}
+
 	// let's suppose param is a proper template ID
 	template, _, err := data.K.TemplateAPI.ReadTemplate(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(kind, id, *template.Id)
 		return *template.Id, nil
 	}
 
@@ -429,7 +797,11 @@ func getTemplateID(ctx context.Context, data *KowabungaProviderData, id, poolId
 	if err == nil {
 		for _, tn := range templates {
 			t, _, err := data.K.TemplateAPI.ReadTemplate(ctx, tn).Execute()
-			if err == nil && t.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(kind, t.Name, *t.Id)
+			if t.Name == id {
 				return *t.Id, nil
 			}
 		}
@@ -438,9 +810,44 @@ func getTemplateID(ctx context.Context, data *KowabungaProviderData, id, poolId
 	return "", fmt.Errorf("%s", ErrorUnknownTemplate)
 }
 
+func getKaktusID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(KaktusResourceName, id); ok {
+		return cached, nil
+	}
+
+	// let's suppose param is a proper kaktus ID
+	kaktus, _, err := data.K.KaktusAPI.ReadKaktus(ctx, id).Execute()
+	if err == nil {
+		data.IDs.set(KaktusResourceName, id, *kaktus.Id)
+		return *kaktus.Id, nil
+	}
+
+	// fall back, it may be a kaktus name then, finds its associated ID
+	kaktuses, _, err := data.K.KaktusAPI.ListKaktuss(ctx).Execute()
+	if err == nil {
+		for _, kn := range kaktuses {
+			k, _, err := data.K.KaktusAPI.ReadKaktus(ctx, kn).Execute()
+			if err != nil {
+				continue
+			}
+			data.IDs.set(KaktusResourceName, k.Name, *k.Id)
+			if k.Name == id {
+				return *k.Id, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%s", ErrorUnknownKaktus)
+}
+
 func getKawaiiID(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+	if cached, ok := data.IDs.get(KawaiiResourceName, id); ok {
+		return cached, nil
+	}
+
 	kawaii, _, err := data.K.KawaiiAPI.ReadKawaii(ctx, id).Execute()
 	if err == nil {
+		data.IDs.set(KawaiiResourceName, id, *kawaii.Id)
 		return *kawaii.Id, nil
 	}
 
@@ -449,10 +856,244 @@ func getKawaiiID(ctx context.Context, data *KowabungaProviderData, id string) (s
 	if err == nil {
 		for _, kw := range kawaiis {
 			t, _, err := data.K.KawaiiAPI.ReadKawaii(ctx, kw).Execute()
-			if err == nil && *t.Name == id {
+			if err != nil {
+				continue
+			}
+			data.IDs.set(KawaiiResourceName, t.GetName(), *t.Id)
+			if t.GetName() == id {
 				return *t.Id, nil
 			}
 		}
 	}
 	return "", fmt.Errorf("%s", ErrorUnknownKawaii)
 }
+
+// getZoneParent looks up the name of the zone a zone-scoped resource belongs to
+// (e.g. to fill in parent reference at import time)
+func getZoneParent(ctx context.Context, data *KowabungaProviderData, id string, list func(zoneId string) ([]string, *http.Response, error)) (string, error) {
+	zone, found, err := findZoneParent(ctx, data, id, list)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("%s", ErrorUnknownZone)
+	}
+	return zone, nil
+}
+
+// findZoneParent returns whether a zone-scoped resource has been found in any
+// zone, zones it can't be listed from (i.e. not found) being skipped, any other
+// API error being returned
+func findZoneParent(ctx context.Context, data *KowabungaProviderData, id string, list func(zoneId string) ([]string, *http.Response, error)) (string, bool, error) {
+	zones, _, err := data.K.ZoneAPI.ListZones(ctx).Execute()
+	if err != nil {
+		return "", false, err
+	}
+	for _, zoneId := range zones {
+		ids, httpResp, err := list(zoneId)
+		if errorIsNotFound(httpResp, err) {
+			continue
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if !slices.Contains(ids, id) {
+			continue
+		}
+		zone, _, err := data.K.ZoneAPI.ReadZone(ctx, zoneId).Execute()
+		if err != nil {
+			return "", false, err
+		}
+		return zone.Name, true, nil
+	}
+	return "", false, nil
+}
+
+// getProjectZoneParents looks up the names of the project and zone a
+// zone-scoped project resource belongs to (e.g. to fill in parent references
+// at import time)
+func getProjectZoneParents(ctx context.Context, data *KowabungaProviderData, id string, list func(projectId, zoneId string) ([]string, *http.Response, error)) (string, string, error) {
+	projects, _, err := data.K.ProjectAPI.ListProjects(ctx).Execute()
+	if err != nil {
+		return "", "", err
+	}
+	for _, projectId := range projects {
+		zone, found, err := findZoneParent(ctx, data, id, func(zoneId string) ([]string, *http.Response, error) {
+			return list(projectId, zoneId)
+		})
+		if err != nil {
+			return "", "", err
+		}
+		if !found {
+			continue
+		}
+		project, _, err := data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+		if err != nil {
+			return "", "", err
+		}
+		return project.Name, zone, nil
+	}
+	return "", "", fmt.Errorf("%s", ErrorUnknownProject)
+}
+
+// getZoneRegionID looks up the ID of the region a zone belongs to
+func getZoneRegionID(ctx context.Context, data *KowabungaProviderData, zoneId string) (string, error) {
+	regions, _, err := data.K.RegionAPI.ListRegions(ctx).Execute()
+	if err != nil {
+		return "", err
+	}
+	for _, regionId := range regions {
+		zones, _, err := data.K.RegionAPI.ListRegionZones(ctx, regionId).Execute()
+		if err == nil && slices.Contains(zones, zoneId) {
+			return regionId, nil
+		}
+	}
+	return "", fmt.Errorf("%s", ErrorUnknownRegion)
+}
+
+// checkRegionCurrency ensures all kaktus nodes and storage pools costs of a
+// region are expressed in the very same currency, as cost reports sum them
+// up without any conversion. It's only a warning if provider explicitly
+// allows mixed currencies, so that a region can be migrated to another
+// currency one object at a time. The object being planned (if already
+// created) is skipped.
+func checkRegionCurrency(ctx context.Context, data *KowabungaProviderData, regionId, currency, self string, diags *diag.Diagnostics) {
+	conflicts := []string{}
+	conflict := func(kind, name, other string) {
+		if other != "" && other != currency {
+			conflicts = append(conflicts, fmt.Sprintf("%s %s costs are expressed in %s", kind, name, other))
+		}
+	}
+	failed := func(err error) {
+		tflog.Warn(ctx, "unable to check region currencies", map[string]any{
+			"region": regionId,
+			"error":  err.Error(),
+		})
+	}
+
+	pools, _, err := data.K.RegionAPI.ListRegionStoragePools(ctx, regionId).Execute()
+	if err != nil {
+		failed(err)
+		return
+	}
+	for _, id := range pools {
+		if id == self {
+			continue
+		}
+		pool, _, err := data.K.PoolAPI.ReadStoragePool(ctx, id).Execute()
+		if err != nil {
+			failed(err)
+			return
+		}
+		conflict(StoragePoolResourceName, pool.Name, pool.Cost.GetCurrency())
+	}
+
+	zones, _, err := data.K.RegionAPI.ListRegionZones(ctx, regionId).Execute()
+	if err != nil {
+		failed(err)
+		return
+	}
+	for _, zoneId := range zones {
+		nodes, _, err := data.K.ZoneAPI.ListZoneKaktuses(ctx, zoneId).Execute()
+		if err != nil {
+			failed(err)
+			return
+		}
+		for _, id := range nodes {
+			if id == self {
+				continue
+			}
+			kaktus, _, err := data.K.KaktusAPI.ReadKaktus(ctx, id).Execute()
+			if err != nil {
+				failed(err)
+				return
+			}
+			conflict(KaktusResourceName, kaktus.Name, kaktus.CpuCost.GetCurrency())
+			if kaktus.MemoryCost.GetCurrency() != kaktus.CpuCost.GetCurrency() {
+				conflict(KaktusResourceName, kaktus.Name, kaktus.MemoryCost.GetCurrency())
+			}
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return
+	}
+	if !data.AllowMixedCurrencies {
+		diags.AddAttributeError(path.Root(KeyCurrency), ErrorCurrencyMismatch,
+			fmt.Sprintf("%s, cannot mix with %s within the same region (see provider's `%s` to migrate a region one object at a time).", strings.Join(conflicts, ", "), currency, KeyAllowMixedCurrencies))
+		return
+	}
+	diags.AddAttributeWarning(path.Root(KeyCurrency), ErrorCurrencyMismatch,
+		fmt.Sprintf("%s, while this one is in %s: region cost reports will be inconsistent until all of its kaktus nodes and storage pools share the same currency.", strings.Join(conflicts, ", "), currency))
+}
+
+var errQuotaExceeded = errors.New(ErrorQuotaExceeded)
+
+// quotaExceeded returns by how much a resource request overflows project's quota (0 being unlimited)
+func quotaExceeded(quota, usage, request int64) int64 {
+	if quota <= 0 || request <= 0 {
+		return 0
+	}
+	return max(usage+request-quota, 0)
+}
+
+// quotaExceededGB returns by how many GB (rounded up) a resource request
+// expressed in bytes overflows project's quota
+func quotaExceededGB(quota, usage, request int64) int64 {
+	x := quotaExceeded(quota, usage, request)
+	return (x + HelperGbToBytes - 1) / HelperGbToBytes
+}
+
+// checkProjectQuotas ensures requested resources fit within project's remaining quotas
+func checkProjectQuotas(ctx context.Context, data *KowabungaProviderData, projectId string, req sdk.ProjectResources) error {
+	project, _, err := data.K.ProjectAPI.ReadProject(ctx, projectId).Execute()
+	if err != nil {
+		return err
+	}
+	if project.Quotas == nil {
+		return nil
+	}
+	usage, _, err := data.K.ProjectAPI.ReadProjectUsage(ctx, projectId).Execute()
+	if err != nil {
+		return err
+	}
+
+	errs := []string{}
+	if x := quotaExceeded(int64(project.Quotas.GetInstances()), int64(usage.GetInstances()), int64(req.GetInstances())); x > 0 {
+		errs = append(errs, fmt.Sprintf("instances quota exceeded by %d", x))
+	}
+	if x := quotaExceeded(int64(project.Quotas.GetVcpus()), int64(usage.GetVcpus()), int64(req.GetVcpus())); x > 0 {
+		errs = append(errs, fmt.Sprintf("vCPUs quota exceeded by %d", x))
+	}
+	if x := quotaExceededGB(project.Quotas.GetMemory(), usage.GetMemory(), req.GetMemory()); x > 0 {
+		errs = append(errs, fmt.Sprintf("memory quota exceeded by %d GB", x))
+	}
+	if x := quotaExceededGB(project.Quotas.GetStorage(), usage.GetStorage(), req.GetStorage()); x > 0 {
+		errs = append(errs, fmt.Sprintf("storage quota exceeded by %d GB", x))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w (%s): %s", errQuotaExceeded, project.Name, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// checks at plan time that a resource to be created fits within project's
+// remaining quotas, each resource being checked on its own. Other failures
+// (e.g. project yet to be created) are left to apply time.
+func planProjectQuotas(ctx context.Context, data *KowabungaProviderData, project types.String, req sdk.ProjectResources, diags *diag.Diagnostics) {
+	if project.IsUnknown() || project.IsNull() {
+		return
+	}
+	projectId, err := getProjectID(ctx, data, project.ValueString())
+	if err != nil {
+		return
+	}
+	err = checkProjectQuotas(ctx, data, projectId, req)
+	if errors.Is(err, errQuotaExceeded) {
+		diags.AddError(ErrorQuotaExceeded, err.Error())
+	} else if err != nil {
+		tflog.Warn(ctx, "unable to check project quotas: "+err.Error())
+	}
+}
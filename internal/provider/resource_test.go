@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResourcePlanParentReplace(t *testing.T) {
+	ctx := context.Background()
+	s := testResourceSchema(NewKawaiiNatRuleResource())
+	data := &KowabungaProviderData{Mutex: &sync.Mutex{}}
+	kawaiis := map[string]string{"gw": "kawaii-1", "kawaii-1": "kawaii-1", "gw2": "kawaii-2", "kawaii-2": "kawaii-2"}
+	resolve := func(ctx context.Context, data *KowabungaProviderData, id string) (string, error) {
+		if k, ok := kawaiis[id]; ok {
+			return k, nil
+		}
+		return "", fmt.Errorf("unknown kawaii %s", id)
+	}
+
+	tests := []struct {
+		name    string
+		prior   string
+		planned any
+		replace bool
+	}{
+		{"unchanged", "gw", "gw", false},
+		{"imported by ID, configured by name", "kawaii-1", "gw", false},
+		{"other kawaii", "kawaii-1", "gw2", true},
+		{"unresolved kawaii", "kawaii-1", "gw3", true},
+		{"unknown kawaii", "kawaii-1", tftypes.UnknownValue, true},
+	}
+	for _, tt := range tests {
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: s, Raw: testResourceValue(s, map[string]tftypes.Value{
+				KeyKawaii: tftypes.NewValue(tftypes.String, tt.prior),
+			})},
+			Plan: tfsdk.Plan{Schema: s, Raw: testResourceValue(s, map[string]tftypes.Value{
+				KeyKawaii: tftypes.NewValue(tftypes.String, tt.planned),
+			})},
+		}
+		var resp resource.ModifyPlanResponse
+		resourcePlanParentReplace(ctx, data, req, &resp, KeyKawaii, resolve)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", tt.name, resp.Diagnostics)
+		}
+		if replace := len(resp.RequiresReplace) > 0; replace != tt.replace {
+			t.Errorf("%s: got replace %v, want %v", tt.name, replace, tt.replace)
+		}
+	}
+}
+
+func TestCheckRegionCurrency(t *testing.T) {
+	// region being migrated from EUR to USD
+	responses := map[string]any{
+		"/api/v1/region/r1/pools":  []string{"p1", "p2"},
+		"/api/v1/pool/p1":          map[string]any{"id": "p1", "name": "ssd", "pool": "ssd", "agents": []string{}, "cost": map[string]any{"price": 10, "currency": "USD"}},
+		"/api/v1/pool/p2":          map[string]any{"id": "p2", "name": "hdd", "pool": "hdd", "agents": []string{}, "cost": map[string]any{"price": 5, "currency": "EUR"}},
+		"/api/v1/region/r1/zones":  []string{"z1"},
+		"/api/v1/zone/z1/kaktuses": []string{"k1"},
+		"/api/v1/kaktus/k1":        map[string]any{"id": "k1", "name": "node", "cpu_cost": map[string]any{"price": 1, "currency": "USD"}, "memory_cost": map[string]any{"price": 1, "currency": "USD"}, "agents": []string{}},
+	}
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		r, ok := responses[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r)
+	})
+	data := &KowabungaProviderData{K: k}
+
+	// last EUR pool being migrated
+	var diags diag.Diagnostics
+	checkRegionCurrency(context.Background(), data, "r1", "USD", "p2", &diags)
+	if len(diags) != 0 {
+		t.Errorf("migrated region: got %v, want no diagnostics", diags)
+	}
+
+	// first object being migrated back, others are still in USD
+	diags = nil
+	checkRegionCurrency(context.Background(), data, "r1", "EUR", "k1", &diags)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("mixed region: got %v, want a single error", diags)
+	}
+
+	// unless mixed currencies are explicitly allowed
+	data.AllowMixedCurrencies = true
+	diags = nil
+	checkRegionCurrency(context.Background(), data, "r1", "EUR", "k1", &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("allowed mixed region: got %v, want a single warning", diags)
+	}
+}
+
+func TestPlanProjectQuotas(t *testing.T) {
+	gb := int64(HelperGbToBytes)
+	responses := map[string]any{
+		"/api/v1/project/p1":       map[string]any{"id": "p1", "name": "acme", "teams": []string{}, "regions": []string{}, "quotas": map[string]any{"vcpus": 8, "memory": 16 * gb, "storage": 100 * gb, "instances": 4}},
+		"/api/v1/project/p1/usage": map[string]any{"vcpus": 4, "memory": 8 * gb, "storage": 90 * gb, "instances": 2},
+	}
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		r, ok := responses[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r)
+	})
+	data := &KowabungaProviderData{K: k, IDs: newIDCache(IDCacheDefaultTTL)}
+
+	// a single byte beyond quota is reported as a whole GB
+	storage := 10*gb + 1
+	var diags diag.Diagnostics
+	planProjectQuotas(context.Background(), data, types.StringValue("p1"), sdk.ProjectResources{Storage: &storage}, &diags)
+	if !diags.HasError() {
+		t.Fatal("expected a quota error")
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "storage quota exceeded by 1 GB") {
+		t.Errorf("got %q, want storage quota exceeded by 1 GB", detail)
+	}
+
+	// fits
+	storage = 10 * gb
+	diags = nil
+	planProjectQuotas(context.Background(), data, types.StringValue("p1"), sdk.ProjectResources{Storage: &storage}, &diags)
+	if diags.HasError() {
+		t.Errorf("got %v, want no error", diags)
+	}
+
+	// unknown project is left to apply time
+	diags = nil
+	planProjectQuotas(context.Background(), data, types.StringValue("p2"), sdk.ProjectResources{Storage: &storage}, &diags)
+	if len(diags) != 0 {
+		t.Errorf("got %v, want no diagnostics", diags)
+	}
+}
+
+// zone-scoped project resources parents lookup only skips projects and zones
+// the resource can't be listed from
+func TestGetProjectZoneParents(t *testing.T) {
+	ctx := context.Background()
+	k := testAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/project":
+			w.Write([]byte(`["p1","p2"]`))
+		case "/api/v1/project/p2":
+			w.Write([]byte(`{"id":"p2","name":"acme","teams":[],"regions":[]}`))
+		case "/api/v1/zone":
+			w.Write([]byte(`["z1"]`))
+		case "/api/v1/zone/z1":
+			w.Write([]byte(`{"id":"z1","name":"eu-west-a"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	data := &KowabungaProviderData{K: k, Mutex: &sync.Mutex{}, IDs: newIDCache(IDCacheDefaultTTL)}
+
+	notFound := &http.Response{StatusCode: http.StatusNotFound}
+	failed := &http.Response{StatusCode: http.StatusUnauthorized}
+	tests := map[string]struct {
+		list    func(projectId, zoneId string) ([]string, *http.Response, error)
+		project string
+		err     string
+	}{
+		"found": {
+			list: func(projectId, zoneId string) ([]string, *http.Response, error) {
+				if projectId == "p1" {
+					return nil, notFound, fmt.Errorf("not found")
+				}
+				return []string{"i1"}, nil, nil
+			},
+			project: "acme",
+		},
+		"unknown": {
+			list: func(projectId, zoneId string) ([]string, *http.Response, error) {
+				return []string{"i2"}, nil, nil
+			},
+			err: ErrorUnknownProject,
+		},
+		"API error": {
+			list: func(projectId, zoneId string) ([]string, *http.Response, error) {
+				return nil, failed, fmt.Errorf("unauthorized")
+			},
+			err: "unauthorized",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			project, zone, err := getProjectZoneParents(ctx, data, "i1", tc.list)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Errorf("got error %v, want %s", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if project != tc.project || zone != "eu-west-a" {
+				t.Errorf("got %s/%s, want %s/eu-west-a", project, zone, tc.project)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	RetryHTTPDefaultMaxRetries = 3
+	RetryHTTPDefaultWait       = "1s"
+	RetryHTTPMaxWait           = 30 * time.Second
+)
+
+// HTTP status codes considered as transient API failures by default
+var retryHTTPDefaultStatusCodes = []int64{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func retryHTTPDefaultStatusCodesString() string {
+	codes := []string{}
+	for _, c := range retryHTTPDefaultStatusCodes {
+		codes = append(codes, strconv.FormatInt(c, 10))
+	}
+	return strings.Join(codes, ", ")
+}
+
+type RetryConfig struct {
+	MaxRetries  int
+	Wait        time.Duration
+	StatusCodes []int64
+}
+
+// retryHTTPTransport replays API requests failing with transient errors, with
+// exponential backoff. Non-idempotent requests (i.e. object creation) are
+// only replayed when the server explicitly rejected them as rate-limited, as
+// they may otherwise have been processed already.
+type retryHTTPTransport struct {
+	transport http.RoundTripper
+	config    RetryConfig
+}
+
+func (t *retryHTTPTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Method == http.MethodPost {
+		return err == nil && resp.StatusCode == http.StatusTooManyRequests
+	}
+	if err != nil {
+		return true
+	}
+	return slices.Contains(t.config.StatusCodes, int64(resp.StatusCode))
+}
+
+// returns the time to wait for before given retry attempt, as requested by
+// server if any, exponentially increasing otherwise
+func (t *retryHTTPTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+			return min(time.Duration(s)*time.Second, RetryHTTPMaxWait)
+		}
+	}
+	return min(t.config.Wait<<attempt, RetryHTTPMaxWait)
+}
+
+func (t *retryHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if req.Body != nil && req.GetBody == nil {
+		// request payload can't be replayed
+		return t.transport.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.transport.RoundTrip(r)
+		if attempt >= t.config.MaxRetries || !t.retryable(r, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		tflog.Warn(ctx, "transient API failure, retrying", fields)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+)
+
+// SDK models returned by the API, per API object kind (see telemetryKind())
+var strictHTTPModels = map[string]reflect.Type{
+	"adapter":  reflect.TypeFor[sdk.Adapter](),
+	"agent":    reflect.TypeFor[sdk.Agent](),
+	"caps":     reflect.TypeFor[sdk.KaktusCaps](),
+	"connect":  reflect.TypeFor[sdk.InstanceRemoteAccess](),
+	"cost":     reflect.TypeFor[sdk.Cost](),
+	"instance": reflect.TypeFor[sdk.Instance](),
+	"ipsec":    reflect.TypeFor[sdk.KawaiiIpSec](),
+	"kaktus":   reflect.TypeFor[sdk.Kaktus](),
+	"kawaii":   reflect.TypeFor[sdk.Kawaii](),
+	"kiwi":     reflect.TypeFor[sdk.Kiwi](),
+	"kompute":  reflect.TypeFor[sdk.Kompute](),
+	"konvey":   reflect.TypeFor[sdk.Konvey](),
+	"kylo":     reflect.TypeFor[sdk.Kylo](),
+	"nfs":      reflect.TypeFor[sdk.StorageNFS](),
+	"pool":     reflect.TypeFor[sdk.StoragePool](),
+	"project":  reflect.TypeFor[sdk.Project](),
+	"record":   reflect.TypeFor[sdk.DnsRecord](),
+	"region":   reflect.TypeFor[sdk.Region](),
+	"state":    reflect.TypeFor[sdk.InstanceState](),
+	"subnet":   reflect.TypeFor[sdk.Subnet](),
+	"team":     reflect.TypeFor[sdk.Team](),
+	"template": reflect.TypeFor[sdk.Template](),
+	"token":    reflect.TypeFor[sdk.ApiToken](),
+	"usage":    reflect.TypeFor[sdk.ProjectResources](),
+	"user":     reflect.TypeFor[sdk.User](),
+	"vnet":     reflect.TypeFor[sdk.VNet](),
+	"volume":   reflect.TypeFor[sdk.Volume](),
+	"zone":     reflect.TypeFor[sdk.Zone](),
+}
+
+const (
+	StrictHTTPErrUnknownFields = "Kowabunga API returned %s fields unknown to the provider (%s), provider may be outdated against platform"
+)
+
+// strictHTTPTransport fails API reads whose response has fields unknown to
+// the SDK models, which SDK decoding either silently drops or fails on, as a
+// hint of provider/SDK version skew against a newer Kahuna server. Writes
+// (which are applied anyway) are only logged.
+type strictHTTPTransport struct {
+	transport http.RoundTripper
+	mutex     sync.Mutex
+	reported  map[string]bool
+}
+
+// returns the API object kind a request path is about, e.g. kawaii for
+// /api/v1/project/{projectId}/region/{regionId}/kawaii
+func strictHTTPKind(path string) string {
+	kind := telemetryKind(http.MethodGet, path)
+	return kind[strings.LastIndexAny(kind, " /")+1:]
+}
+
+// returns the JSON fields names of a SDK model
+func strictHTTPModelFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// returns the (dot-separated) paths of JSON value fields unknown to a SDK
+// model, nested objects included
+func strictHTTPUnknownFields(prefix string, t reflect.Type, v any) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	unknown := []string{}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			break
+		}
+		fields := strictHTTPModelFields(t)
+		for key, value := range obj {
+			ft, ok := fields[key]
+			if !ok {
+				unknown = append(unknown, prefix+key)
+				continue
+			}
+			unknown = append(unknown, strictHTTPUnknownFields(prefix+key+".", ft, value)...)
+		}
+	case reflect.Slice:
+		items, ok := v.([]any)
+		if !ok {
+			break
+		}
+		for _, item := range items {
+			unknown = append(unknown, strictHTTPUnknownFields(prefix, t.Elem(), item)...)
+		}
+	}
+
+	slices.Sort(unknown)
+	return slices.Compact(unknown)
+}
+
+func (t *strictHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode >= http.StatusMultipleChoices || !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return resp, err
+	}
+
+	kind := strictHTTPKind(req.URL.Path)
+	model, ok := strictHTTPModels[kind]
+	if !ok {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+
+	// lists of objects IDs are not checked
+	var obj map[string]any
+	if json.Unmarshal(body, &obj) != nil {
+		return resp, nil
+	}
+
+	unknown := strictHTTPUnknownFields("", model, obj)
+	if len(unknown) > 0 && req.Method == http.MethodGet {
+		return nil, fmt.Errorf(StrictHTTPErrUnknownFields, kind, strings.Join(unknown, ", "))
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for _, field := range unknown {
+		// warn only once per object kind field
+		if t.reported[kind+"/"+field] {
+			continue
+		}
+		t.reported[kind+"/"+field] = true
+		tflog.Warn(req.Context(), "Unknown field returned by Kowabunga API, provider may be outdated against platform", map[string]any{
+			"kind":  kind,
+			"field": field,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	sdk "github.com/kowabunga-cloud/kowabunga-go"
+)
+
+func TestStrictHTTPKind(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/kawaii/k1":                   "kawaii",
+		"/api/v1/project/p1/region/r1/kawaii": "kawaii",
+		"/api/v1/kompute/k1/state":            "state",
+		"/api/v1/project/p1/usage":            "usage",
+	}
+	for path, want := range tests {
+		if got := strictHTTPKind(path); got != want {
+			t.Errorf("%s: got %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestStrictHTTPUnknownFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		model reflect.Type
+		json  string
+		want  []string
+	}{
+		{"known fields", reflect.TypeFor[sdk.Cost](), `{"price":1,"currency":"EUR"}`, []string{}},
+		{"unknown field", reflect.TypeFor[sdk.Cost](), `{"price":1,"currency":"EUR","vat":20}`, []string{"vat"}},
+		{"nested pointer", reflect.TypeFor[sdk.Kaktus](), `{"name":"k","cpu_cost":{"price":1,"unit":"h"}}`, []string{"cpu_cost.unit"}},
+		{"slice items, reported once", reflect.TypeFor[sdk.Kawaii](), `{"dnat":[{"ports":"80","zone":"a"},{"ports":"443","zone":"b"}]}`, []string{"dnat.zone"}},
+		{"unexpected value type", reflect.TypeFor[sdk.Kawaii](), `{"dnat":"none"}`, []string{}},
+	}
+	for _, tt := range tests {
+		var v any
+		if err := json.Unmarshal([]byte(tt.json), &v); err != nil {
+			t.Fatal(err)
+		}
+		if got := strictHTTPUnknownFields("", tt.model, v); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStrictHTTPTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"z1","name":"zone","region":"r1"}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := &http.Client{Transport: &strictHTTPTransport{
+		transport: http.DefaultTransport,
+		reported:  map[string]bool{},
+	}}
+
+	// reads fail, writes (applied anyway) don't
+	resp, err := client.Get(srv.URL + "/api/v1/zone/z1")
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Error("read with unknown field: got no error")
+	}
+	req, _ := http.NewRequest(http.MethodPut, srv.URL+"/api/v1/zone/z1", nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("write with unknown field: got %v", err)
+	}
+	_ = resp.Body.Close()
+}
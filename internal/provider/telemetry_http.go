@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TelemetryAPIBasePath = "/api/v1"
+)
+
+// telemetry keeps track of API calls count and duration per API object kind,
+// for the whole provider process lifetime
+type telemetry struct {
+	mutex sync.Mutex
+	stats map[string]*telemetryStats
+}
+
+type telemetryStats struct {
+	kind     string
+	calls    int
+	errors   int
+	duration time.Duration
+}
+
+var apiTelemetry = &telemetry{
+	stats: map[string]*telemetryStats{},
+}
+
+// returns API object kind out of request path, with objects IDs stripped,
+// e.g. /api/v1/project/{projectId}/region/{regionId}/kawaii is accounted as
+// project/region/kawaii
+func telemetryKind(method, path string) string {
+	path = strings.TrimPrefix(path, TelemetryAPIBasePath)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	kind := []string{}
+	for i, s := range segments {
+		if i%2 == 0 {
+			kind = append(kind, s)
+		}
+	}
+	return method + " " + strings.Join(kind, "/")
+}
+
+func (t *telemetry) record(kind string, duration time.Duration, failed bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s, ok := t.stats[kind]
+	if !ok {
+		s = &telemetryStats{kind: kind}
+		t.stats[kind] = s
+	}
+	s.calls++
+	s.duration += duration
+	if failed {
+		s.errors++
+	}
+}
+
+// returns a human-readable summary of API calls, slowest kinds first, or an
+// empty one if no call has been issued
+func (t *telemetry) summary() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.stats) == 0 {
+		return nil
+	}
+
+	stats := []*telemetryStats{}
+	calls := 0
+	var total time.Duration
+	for _, s := range t.stats {
+		stats = append(stats, s)
+		calls += s.calls
+		total += s.duration
+	}
+	slices.SortFunc(stats, func(a, b *telemetryStats) int {
+		return cmp.Or(cmp.Compare(b.duration, a.duration), strings.Compare(a.kind, b.kind))
+	})
+
+	lines := []string{
+		fmt.Sprintf("Kowabunga API calls summary: %d calls, %s total", calls, total.Round(time.Millisecond)),
+	}
+	for _, s := range stats {
+		lines = append(lines, fmt.Sprintf("  %s: %d calls (%d failed), %s total, %s average",
+			s.kind, s.calls, s.errors, s.duration.Round(time.Millisecond), (s.duration/time.Duration(s.calls)).Round(time.Millisecond)))
+	}
+	return lines
+}
+
+// TelemetrySummary returns the summary of all Kowabunga API calls issued so
+// far by the provider, to be logged once done serving Terraform requests
+func TelemetrySummary() []string {
+	return apiTelemetry.summary()
+}
+
+type telemetryHTTPTransport struct {
+	transport http.RoundTripper
+	telemetry *telemetry
+}
+
+func (t *telemetryHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	failed := err != nil || resp.StatusCode >= http.StatusBadRequest
+	t.telemetry.record(telemetryKind(req.Method, req.URL.Path), time.Since(start), failed)
+	return resp, err
+}
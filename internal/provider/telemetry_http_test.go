@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTelemetrySummary(t *testing.T) {
+	tm := &telemetry{
+		stats: map[string]*telemetryStats{},
+	}
+	if got := tm.summary(); got != nil {
+		t.Errorf("summary without calls: got %v, want none", got)
+	}
+
+	for range 3 {
+		tm.record(telemetryKind("GET", "/api/v1/project/p1/kompute"), time.Millisecond, false)
+	}
+	tm.record(telemetryKind("PUT", "/api/v1/kompute/k1"), 10*time.Millisecond, true)
+
+	// a single summary covers all calls, slowest kinds first
+	got := tm.summary()
+	if len(got) != 3 {
+		t.Fatalf("summary: got %v, want 3 lines", got)
+	}
+	if !strings.Contains(got[0], "4 calls") || !strings.HasPrefix(got[1], "  PUT kompute: 1 calls (1 failed)") || !strings.HasPrefix(got[2], "  GET project/kompute: 3 calls (0 failed)") {
+		t.Errorf("summary: got %q", got)
+	}
+}
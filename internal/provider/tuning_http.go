@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	HTTPDefaultMaxIdleConns        = 100
+	HTTPDefaultMaxIdleConnsPerHost = 32 // Go's default (2) makes large refreshes churn through connections
+	HTTPDefaultIdleConnTimeout     = "90s"
+	HTTPDefaultKeepAlive           = "30s"
+	HTTPDefaultTimeout             = "0s"
+	HTTPDialTimeout                = 30 * time.Second
+)
+
+// HTTPConfig holds API client connection pool and timeouts tuning
+type HTTPConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	Timeout             time.Duration // overall per-request timeout, 0 for none
+}
+
+// returns an HTTP transport with tuned connection pooling, all Kahuna API
+// requests targeting the very same endpoint
+func newTunedHTTPTransport(cfg HTTPConfig) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = (&net.Dialer{
+		Timeout:   HTTPDialTimeout,
+		KeepAlive: cfg.KeepAlive,
+	}).DialContext
+	t.MaxIdleConns = cfg.MaxIdleConns
+	t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	t.IdleConnTimeout = cfg.IdleConnTimeout
+	return t
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = NetworkAddressType{}
+var _ basetypes.StringValuableWithSemanticEquals = NetworkAddressValue{}
+
+// NetworkAddressType is a string type for IPv4 addresses or CIDRs, which
+// Kowabunga API may normalize (e.g. 10.0.0.1 as 10.0.0.1/32)
+type NetworkAddressType struct {
+	basetypes.StringType
+}
+
+func (t NetworkAddressType) String() string {
+	return "NetworkAddressType"
+}
+
+func (t NetworkAddressType) Equal(o attr.Type) bool {
+	other, ok := o.(NetworkAddressType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t NetworkAddressType) ValueType(ctx context.Context) attr.Value {
+	return NetworkAddressValue{}
+}
+
+func (t NetworkAddressType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return NetworkAddressValue{StringValue: in}, nil
+}
+
+func (t NetworkAddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	v, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", v)
+	}
+	return NetworkAddressValue{StringValue: s}, nil
+}
+
+// NetworkAddressValue holds an IPv4 address or CIDR
+type NetworkAddressValue struct {
+	basetypes.StringValue
+}
+
+func NewNetworkAddressValue(address string) NetworkAddressValue {
+	return NetworkAddressValue{StringValue: types.StringValue(address)}
+}
+
+func (v NetworkAddressValue) Type(ctx context.Context) attr.Type {
+	return NetworkAddressType{}
+}
+
+func (v NetworkAddressValue) Equal(o attr.Value) bool {
+	other, ok := o.(NetworkAddressValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// returns the network an address or CIDR covers, a bare address being a
+// single host one, e.g. 10.0.0.1/24 gives 10.0.0.0/24
+func networkAddressNormalize(address string) (string, bool) {
+	if !strings.Contains(address, "/") {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return "", false
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			bits = 8 * net.IPv4len
+		}
+		address = fmt.Sprintf("%s/%d", address, bits)
+	}
+	_, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return "", false
+	}
+	return network.String(), true
+}
+
+// an address read back from the API is semantically equal to the prior one
+// as long as both cover the very same network
+func (v NetworkAddressValue) StringSemanticEquals(ctx context.Context, prior basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	p, ok := prior.(NetworkAddressValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("unexpected value type of %T", prior))
+		return false, diags
+	}
+	if v.IsNull() || v.IsUnknown() || p.IsNull() || p.IsUnknown() {
+		return v.Equal(p), diags
+	}
+
+	actual, ok := networkAddressNormalize(v.ValueString())
+	if !ok {
+		return v.Equal(p), diags
+	}
+	expected, ok := networkAddressNormalize(p.ValueString())
+	if !ok {
+		return v.Equal(p), diags
+	}
+	return actual == expected, diags
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNetworkAddressNormalize(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+		ok      bool
+	}{
+		{address: "10.0.0.1", want: "10.0.0.1/32", ok: true},
+		{address: "10.0.0.1/32", want: "10.0.0.1/32", ok: true},
+		{address: "10.0.0.0/24", want: "10.0.0.0/24", ok: true},
+		{address: "2001:db8::1", want: "2001:db8::1/128", ok: true},
+		{address: "2001:db8::1/128", want: "2001:db8::1/128", ok: true},
+		{address: "2001:db8::/64", want: "2001:db8::/64", ok: true},
+		{address: "10.0.0.256"},
+		{address: "10.0.0.0/33"},
+		{address: "any"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.address, func(t *testing.T) {
+			got, ok := networkAddressNormalize(tc.address)
+			if ok != tc.ok || got != tc.want {
+				t.Errorf("got %q (%v), want %q (%v)", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestNetworkAddressSemanticEquals(t *testing.T) {
+	tests := []struct {
+		actual NetworkAddressValue
+		prior  NetworkAddressValue
+		want   bool
+	}{
+		{actual: NewNetworkAddressValue("10.0.0.1/32"), prior: NewNetworkAddressValue("10.0.0.1"), want: true},
+		{actual: NewNetworkAddressValue("2001:db8::1/128"), prior: NewNetworkAddressValue("2001:db8::1"), want: true},
+		{actual: NewNetworkAddressValue("10.0.0.0/24"), prior: NewNetworkAddressValue("10.0.0.0/24"), want: true},
+		{actual: NewNetworkAddressValue("10.0.0.1/24"), prior: NewNetworkAddressValue("10.0.0.1"), want: false},
+		{actual: NewNetworkAddressValue("10.0.0.2/32"), prior: NewNetworkAddressValue("10.0.0.1"), want: false},
+		{actual: NewNetworkAddressValue("any"), prior: NewNetworkAddressValue("any"), want: true},
+		{actual: NewNetworkAddressValue("any"), prior: NewNetworkAddressValue("10.0.0.1"), want: false},
+		{actual: NetworkAddressValue{StringValue: types.StringNull()}, prior: NewNetworkAddressValue("10.0.0.1"), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.actual.String()+"~"+tc.prior.String(), func(t *testing.T) {
+			got, diags := tc.actual.StringSemanticEquals(context.Background(), tc.prior)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = PortRangesType{}
+var _ basetypes.StringValuableWithSemanticEquals = PortRangesValue{}
+
+// PortRangesType is a string type for comma-separated lists of ports (or
+// ranges of ports), which Kowabunga API may reorder
+type PortRangesType struct {
+	basetypes.StringType
+}
+
+func (t PortRangesType) String() string {
+	return "PortRangesType"
+}
+
+func (t PortRangesType) Equal(o attr.Type) bool {
+	other, ok := o.(PortRangesType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t PortRangesType) ValueType(ctx context.Context) attr.Value {
+	return PortRangesValue{}
+}
+
+func (t PortRangesType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return PortRangesValue{StringValue: in}, nil
+}
+
+func (t PortRangesType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	v, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", v)
+	}
+	return PortRangesValue{StringValue: s}, nil
+}
+
+// PortRangesValue holds a list of ports (or ranges of ports), e.g. 80,443
+type PortRangesValue struct {
+	basetypes.StringValue
+}
+
+func NewPortRangesValue(ports string) PortRangesValue {
+	return PortRangesValue{StringValue: types.StringValue(ports)}
+}
+
+func (v PortRangesValue) Type(ctx context.Context) attr.Type {
+	return PortRangesType{}
+}
+
+func (v PortRangesValue) Equal(o attr.Value) bool {
+	other, ok := o.(PortRangesValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// returns ports ranges sorted and deduplicated, single port ranges being
+// collapsed, e.g. 443,80-80,443 gives 80,443
+func portRangesNormalize(ports string) (string, bool) {
+	type portRange struct {
+		first, last uint64
+	}
+
+	ranges := []portRange{}
+	for _, p := range strings.Split(ports, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(p, "-")
+		lo, err := strconv.ParseUint(strings.TrimSpace(first), 10, 16)
+		if err != nil {
+			return "", false
+		}
+		hi := lo
+		if isRange {
+			hi, err = strconv.ParseUint(strings.TrimSpace(last), 10, 16)
+			if err != nil {
+				return "", false
+			}
+		}
+		ranges = append(ranges, portRange{lo, hi})
+	}
+
+	slices.SortFunc(ranges, func(a, b portRange) int {
+		return cmp.Or(cmp.Compare(a.first, b.first), cmp.Compare(a.last, b.last))
+	})
+	ranges = slices.Compact(ranges)
+
+	normalized := []string{}
+	for _, r := range ranges {
+		if r.first == r.last {
+			normalized = append(normalized, strconv.FormatUint(r.first, 10))
+			continue
+		}
+		normalized = append(normalized, fmt.Sprintf("%d-%d", r.first, r.last))
+	}
+	return strings.Join(normalized, ","), true
+}
+
+// ports read back from the API are semantically equal to the prior ones as
+// long as they describe the very same ranges, whichever order they come in
+func (v PortRangesValue) StringSemanticEquals(ctx context.Context, prior basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	p, ok := prior.(PortRangesValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("unexpected value type of %T", prior))
+		return false, diags
+	}
+	if v.IsNull() || v.IsUnknown() || p.IsNull() || p.IsUnknown() {
+		return v.Equal(p), diags
+	}
+
+	actual, ok := portRangesNormalize(v.ValueString())
+	if !ok {
+		return v.Equal(p), diags
+	}
+	expected, ok := portRangesNormalize(p.ValueString())
+	if !ok {
+		return v.Equal(p), diags
+	}
+	return actual == expected, diags
+}
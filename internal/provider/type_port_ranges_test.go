@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPortRangesNormalize(t *testing.T) {
+	tests := []struct {
+		ports string
+		want  string
+		ok    bool
+	}{
+		{ports: "80", want: "80", ok: true},
+		{ports: "443,80", want: "80,443", ok: true},
+		{ports: "80-80", want: "80", ok: true},
+		{ports: "443,80-80,443", want: "80,443", ok: true},
+		{ports: "8000-8080, 22", want: "22,8000-8080", ok: true},
+		{ports: "1000-2000,1000-1500", want: "1000-1500,1000-2000", ok: true},
+		{ports: "", want: "", ok: true},
+		{ports: "http"},
+		{ports: "80-"},
+		{ports: "65536"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.ports, func(t *testing.T) {
+			got, ok := portRangesNormalize(tc.ports)
+			if ok != tc.ok || got != tc.want {
+				t.Errorf("got %q (%v), want %q (%v)", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestPortRangesSemanticEquals(t *testing.T) {
+	tests := []struct {
+		actual PortRangesValue
+		prior  PortRangesValue
+		want   bool
+	}{
+		{actual: NewPortRangesValue("80,443"), prior: NewPortRangesValue("443,80"), want: true},
+		{actual: NewPortRangesValue("80"), prior: NewPortRangesValue("80-80"), want: true},
+		{actual: NewPortRangesValue("80,443"), prior: NewPortRangesValue("80"), want: false},
+		{actual: NewPortRangesValue("80-81"), prior: NewPortRangesValue("80,81"), want: false},
+		{actual: NewPortRangesValue("any"), prior: NewPortRangesValue("any"), want: true},
+		{actual: NewPortRangesValue("any"), prior: NewPortRangesValue("80"), want: false},
+		{actual: PortRangesValue{StringValue: types.StringNull()}, prior: NewPortRangesValue("80"), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.actual.String()+"~"+tc.prior.String(), func(t *testing.T) {
+			got, diags := tc.actual.StringSemanticEquals(context.Background(), tc.prior)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.Int64Typable = SizeGBType{}
+var _ basetypes.Int64Valuable = SizeGBValue{}
+
+// SizeGBType is an int64 type for memory and disk sizes, expressed in GB on
+// Terraform side but in bytes on Kowabunga API side
+type SizeGBType struct {
+	basetypes.Int64Type
+}
+
+func (t SizeGBType) String() string {
+	return "SizeGBType"
+}
+
+func (t SizeGBType) Equal(o attr.Type) bool {
+	other, ok := o.(SizeGBType)
+	if !ok {
+		return false
+	}
+	return t.Int64Type.Equal(other.Int64Type)
+}
+
+func (t SizeGBType) ValueType(ctx context.Context) attr.Value {
+	return SizeGBValue{}
+}
+
+func (t SizeGBType) ValueFromInt64(ctx context.Context, in basetypes.Int64Value) (basetypes.Int64Valuable, diag.Diagnostics) {
+	return SizeGBValue{Int64Value: in}, nil
+}
+
+func (t SizeGBType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	v, err := t.Int64Type.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := v.(basetypes.Int64Value)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", v)
+	}
+	return SizeGBValue{Int64Value: i}, nil
+}
+
+// SizeGBValue holds a size in GB
+type SizeGBValue struct {
+	basetypes.Int64Value
+}
+
+func NewSizeGBValue(gb int64) SizeGBValue {
+	return SizeGBValue{Int64Value: types.Int64Value(gb)}
+}
+
+func NewSizeGBNull() SizeGBValue {
+	return SizeGBValue{Int64Value: types.Int64Null()}
+}
+
+// converts an API size in bytes, rounded to the nearest GB, so that a size
+// the API slightly adjusted (e.g. aligned on blocks) doesn't show up as drift
+func NewSizeGBValueFromBytes(bytes int64) SizeGBValue {
+	return SizeGBValue{
+		Int64Value: types.Int64Value((bytes + HelperGbToBytes/2) / HelperGbToBytes),
+	}
+}
+
+func (v SizeGBValue) Type(ctx context.Context) attr.Type {
+	return SizeGBType{}
+}
+
+func (v SizeGBValue) Equal(o attr.Value) bool {
+	other, ok := o.(SizeGBValue)
+	if !ok {
+		return false
+	}
+	return v.Int64Value.Equal(other.Int64Value)
+}
+
+// returns size in bytes, as expected by Kowabunga API
+func (v SizeGBValue) ValueBytes() int64 {
+	return v.ValueInt64() * HelperGbToBytes
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+const (
+	ValidatorIPsecStartActionDescription = "IPsec start action only supports the following : "
+	ValidatorIPsecDpdActionDescription   = "IPsec DPD action only supports the following : "
+	ValidatorIPsecActionErrUnsupported   = "Unsupported action"
+
+	IPsecStartActionNone      = "none"
+	IPsecStartActionStart     = "start"
+	IPsecStartActionTrap      = "trap"
+	IPsecStartActionTrapStart = "trap|start"
+
+	IPsecDpdActionClear   = "clear"
+	IPsecDpdActionTrap    = "trap"
+	IPsecDpdActionRestart = "restart"
+)
+
+var ipsecSupportedStartActions = []string{
+	IPsecStartActionNone,
+	IPsecStartActionStart,
+	IPsecStartActionTrap,
+	IPsecStartActionTrapStart,
+}
+
+var ipsecSupportedDpdActions = []string{
+	IPsecDpdActionClear,
+	IPsecDpdActionTrap,
+	IPsecDpdActionRestart,
+}
+
+// start actions installing a trap policy, for the tunnel to be negotiated
+// on-demand (and re-negotiated as such once closed)
+var ipsecTrapStartActions = []string{
+	IPsecStartActionTrap,
+	IPsecStartActionTrapStart,
+}
+
+// on-demand (trap policy) tunnels must not be eagerly restarted on peer loss
+var ipsecTrapCompatibleDpdActions = []string{
+	IPsecDpdActionClear,
+	IPsecDpdActionTrap,
+}
+
+type stringIPsecStartActionValidator struct{}
+
+func (v stringIPsecStartActionValidator) Description(ctx context.Context) string {
+	return ValidatorIPsecStartActionDescription + strings.Join(ipsecSupportedStartActions, ", ")
+}
+
+func (v stringIPsecStartActionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIPsecStartActionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !slices.Contains(ipsecSupportedStartActions, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			ValidatorIPsecActionErrUnsupported,
+			fmt.Sprintf("%s. Got : %s", v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+		return
+	}
+}
+
+type stringIPsecDpdActionValidator struct{}
+
+func (v stringIPsecDpdActionValidator) Description(ctx context.Context) string {
+	return ValidatorIPsecDpdActionDescription + strings.Join(ipsecSupportedDpdActions, ", ")
+}
+
+func (v stringIPsecDpdActionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIPsecDpdActionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !slices.Contains(ipsecSupportedDpdActions, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			ValidatorIPsecActionErrUnsupported,
+			fmt.Sprintf("%s. Got : %s", v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+		return
+	}
+}
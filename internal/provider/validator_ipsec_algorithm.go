@@ -43,7 +43,7 @@ var diffieHellmanSupportedTypes = []int64{
 type diffieHellmanAlgorithmTypeValidator struct{}
 
 func (v diffieHellmanAlgorithmTypeValidator) Description(ctx context.Context) string {
-	return ValidatorIntegrityAlgorithmDescription + strings.Join(integritySupportedTypes, ", ")
+	return ValidatorDHAlgorithmDescription + fmt.Sprint(diffieHellmanSupportedTypes)
 }
 
 func (v diffieHellmanAlgorithmTypeValidator) MarkdownDescription(ctx context.Context) string {
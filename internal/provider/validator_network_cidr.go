@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) The Kowabunga Project
+ * Apache License, Version 2.0 (see LICENSE or https://www.apache.org/licenses/LICENSE-2.0.txt)
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+const (
+	ValidatorNetworkCIDRDescription = "String must be a valid IPv4 CIDR"
+	ValidatorNetworkCIDRErrInvalid  = "Invalid IPv4 CIDR"
+)
+
+type stringNetworkCIDRValidator struct{}
+
+func (v stringNetworkCIDRValidator) Description(ctx context.Context) string {
+	return ValidatorNetworkCIDRDescription
+}
+
+func (v stringNetworkCIDRValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringNetworkCIDRValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	cidr := req.ConfigValue.ValueString()
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || !prefix.Addr().Is4() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			ValidatorNetworkCIDRErrInvalid,
+			fmt.Sprintf("%s: %s", ValidatorNetworkCIDRErrInvalid, cidr),
+		)
+	}
+}
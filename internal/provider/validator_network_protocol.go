@@ -13,11 +13,17 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
-	ValidatorNetworkProtocolDescription    = "Protocol must be one of 'udp, 'tcp'"
-	ValidatorNetworkProtocolErrUnsupported = "Unsupported protocol"
+	ValidatorNetworkProtocolDescription         = "Protocol must be one of 'udp, 'tcp'"
+	ValidatorNetworkProtocolPortlessDescription = "Protocol must be one of 'udp, 'tcp', 'esp', 'gre', 'ah'"
+	ValidatorNetworkProtocolErrUnsupported      = "Unsupported protocol"
+
+	ValidatorNetworkProtocolPortsDescription = "Ports are required for 'tcp' and 'udp' protocols and must be left empty for 'esp', 'gre' and 'ah' ones"
+	ValidatorNetworkProtocolErrPortsRequired = "Missing ports"
+	ValidatorNetworkProtocolErrPortsDenied   = "Unexpected ports"
 )
 
 var networkSupportedProtocols = []string{
@@ -25,9 +31,25 @@ var networkSupportedProtocols = []string{
 	"udp",
 }
 
-type stringNetworkProtocolValidator struct{}
+// transport protocols with no notion of ports (VPN and tunneling ones)
+var networkPortlessProtocols = []string{
+	"esp",
+	"gre",
+	"ah",
+}
+
+func networkProtocolIsPortless(protocol string) bool {
+	return slices.Contains(networkPortlessProtocols, strings.ToLower(protocol))
+}
+
+type stringNetworkProtocolValidator struct {
+	portless bool // whether portless protocols are accepted as well
+}
 
 func (v stringNetworkProtocolValidator) Description(ctx context.Context) string {
+	if v.portless {
+		return ValidatorNetworkProtocolPortlessDescription
+	}
 	return ValidatorNetworkProtocolDescription
 }
 
@@ -42,6 +64,9 @@ func (v stringNetworkProtocolValidator) ValidateString(ctx context.Context, req
 	}
 
 	protocol := req.ConfigValue.ValueString()
+	if v.portless && networkProtocolIsPortless(protocol) {
+		return
+	}
 	if !slices.Contains(networkSupportedProtocols, strings.ToLower(protocol)) {
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
@@ -50,3 +75,52 @@ func (v stringNetworkProtocolValidator) ValidateString(ctx context.Context, req
 		)
 	}
 }
+
+// stringNetworkProtocolPortsValidator ensures ports are only set for
+// protocols supporting them, as specified by the sibling protocol attribute
+type stringNetworkProtocolPortsValidator struct{}
+
+func (v stringNetworkProtocolPortsValidator) Description(ctx context.Context) string {
+	return ValidatorNetworkProtocolPortsDescription
+}
+
+func (v stringNetworkProtocolPortsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringNetworkProtocolPortsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var protocol types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName(KeyProtocol), &protocol)...)
+	if resp.Diagnostics.HasError() || protocol.IsUnknown() {
+		return
+	}
+
+	ports := req.ConfigValue.ValueString()
+	if networkProtocolIsPortless(protocol.ValueString()) {
+		if ports != "" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				ValidatorNetworkProtocolErrPortsDenied,
+				fmt.Sprintf("%s: %s", ValidatorNetworkProtocolPortsDescription, ports),
+			)
+		}
+		return
+	}
+
+	if ports == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			ValidatorNetworkProtocolErrPortsRequired,
+			ValidatorNetworkProtocolPortsDescription,
+		)
+		return
+	}
+
+	// regular port ranges validation
+	stringNetworkPortRangesValidator{}.ValidateString(ctx, req, resp)
+}
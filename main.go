@@ -30,6 +30,12 @@ func main() {
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Terraform is done with the provider, summarize API usage for performance tuning
+	for _, line := range provider.TelemetrySummary() {
+		log.Printf("[INFO] %s", line)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}